@@ -2,7 +2,10 @@ package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"slices"
 	"strings"
@@ -85,6 +88,13 @@ func main() {
 
 	// fmt.Printf("Setting token: %v", response.Token)
 
+	if len(os.Args) > 1 && os.Args[1] == "followers-diff" {
+		if err := followersDiff(pf, os.Args[2:]); err != nil {
+			fmt.Printf("Could not diff followers: %v\n", err)
+		}
+		return
+	}
+
 	var names []string
 
 	err = pf.GetMe()
@@ -132,3 +142,46 @@ func main() {
 	}
 
 }
+
+// followersDiff compares your followers with the snapshot saved by the
+// previous run, prints who followed and unfollowed since, then saves a
+// new snapshot for next time.
+//
+// usage: primfeed followers-diff [-snapshot followers.json]
+func followersDiff(pf *primfeed.Primfeed, args []string) error {
+	flags := flag.NewFlagSet("followers-diff", flag.ExitOnError)
+	path := flags.String("snapshot", "followers.json", "file holding the previous follower snapshot")
+	flags.Parse(args)
+
+	err := pf.GetMe()
+	if err != nil {
+		return err
+	}
+
+	current := pf.SnapshotFollowers()
+
+	previous, err := primfeed.LoadFollowerSnapshot(*path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("No snapshot yet, saving %d followers to %s\n", len(current.Followers), *path)
+		return current.Save(*path)
+	}
+	if err != nil {
+		return err
+	}
+
+	diff := previous.Diff(current.Followers)
+
+	fmt.Printf("Since %s:\n", previous.TakenAt.Local().Format("2006-01-02 15:04"))
+	for _, f := range diff.Added {
+		fmt.Printf("  + %s\n", f.Handle)
+	}
+	for _, f := range diff.Removed {
+		fmt.Printf("  - %s\n", f.Handle)
+	}
+	for _, r := range diff.Renamed {
+		fmt.Printf("  ~ %s is now %s\n", r.From, r.To)
+	}
+	fmt.Printf("%d new, %d lost, %d total\n", len(diff.Added), len(diff.Removed), len(current.Followers))
+
+	return current.Save(*path)
+}
@@ -1,6 +1,6 @@
 module github.com/afallenhope/primfeed
 
-go 1.22.2
+go 1.23
 
 require github.com/stretchr/testify v1.9.0
 
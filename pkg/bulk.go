@@ -0,0 +1,151 @@
+package primfeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrCannotFollow is returned for entities whose profile says they can't
+// be followed (UserProfile.CanFollow is false).
+var ErrCannotFollow = errors.New("cannot follow")
+
+// ErrEmptyTarget is returned for a Target with neither an ID nor a handle.
+var ErrEmptyTarget = errors.New("target has no ID or handle")
+
+// Target names an entity for the bulk methods, by ID or by handle.
+// Follows and dry runs load the profile of every target from /entity/,
+// by ID or handle, to check it. Other unfollows by handle resolve it
+// through the handle cache, and by ID skip the lookup.
+type Target struct {
+	ID     string
+	Handle string
+}
+
+func ByID(id string) Target {
+	return Target{ID: id}
+}
+
+func ByHandle(handle string) Target {
+	return Target{Handle: handle}
+}
+
+func (t Target) String() string {
+	if t.Handle != "" {
+		return t.Handle
+	}
+
+	return t.ID
+}
+
+type BulkOptions struct {
+	// DryRun resolves and checks every target but doesn't follow or
+	// unfollow anything.
+	DryRun bool
+}
+
+// BulkResult is the outcome for one target.
+type BulkResult struct {
+	Target Target
+
+	// Profile is the loaded profile. Outside dry runs, only Profile.User
+	// is set for unfollows by handle, which may come from the handle
+	// cache, and nothing for unfollows by ID.
+	Profile UserProfile
+
+	// DryRun is true when the action was only checked, not performed.
+	DryRun bool
+
+	// Err is a *BulkError, or nil on success.
+	Err error
+}
+
+// BulkError says which target and which step failed. It wraps the
+// underlying error, so errors.Is works with ErrCannotFollow, ErrNotFound
+// and the other sentinels.
+type BulkError struct {
+	Op     string
+	Target Target
+	Err    error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Op, e.Target, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// BulkFollow follows every target, running at most WithMaxConcurrency
+// at a time. Results line up with targets.
+func (p *Primfeed) BulkFollow(ctx context.Context, targets []Target, opts BulkOptions) []BulkResult {
+	return p.bulk(ctx, "follow", targets, opts, p.FollowByIdContext)
+}
+
+// BulkUnfollow unfollows every target, running at most
+// WithMaxConcurrency at a time. Results line up with targets.
+func (p *Primfeed) BulkUnfollow(ctx context.Context, targets []Target, opts BulkOptions) []BulkResult {
+	return p.bulk(ctx, "unfollow", targets, opts, p.UnfollowByIdContext)
+}
+
+func (p *Primfeed) bulk(ctx context.Context, op string, targets []Target, opts BulkOptions, act func(ctx context.Context, id string) error) []BulkResult {
+	results := make([]BulkResult, len(targets))
+
+	p.forEach(ctx, len(targets), func(ctx context.Context, i int) {
+		target := targets[i]
+		result := BulkResult{Target: target, DryRun: opts.DryRun}
+		fail := func(step string, err error) {
+			result.Err = &BulkError{Op: step, Target: target, Err: err}
+		}
+
+		id := target.ID
+		switch {
+		case target.Handle == "" && target.ID == "":
+			fail("resolve", ErrEmptyTarget)
+			results[i] = result
+			return
+		case target.Handle != "" && op != "follow" && !opts.DryRun:
+			user, resolved, err := p.actOnHandle(ctx, target.Handle, act)
+			result.Profile.User = user
+			if err != nil && !resolved {
+				fail("resolve", err)
+			} else if err != nil {
+				fail(op, err)
+			}
+
+			results[i] = result
+			return
+		case target.Handle != "" || op == "follow" || opts.DryRun:
+			// CanFollow isn't part of User, so follows always need the
+			// full profile. Dry runs load it to check the target exists.
+			profile, err := p.GetUserProfileContext(ctx, target.String())
+			if err != nil {
+				fail("resolve", err)
+				results[i] = result
+				return
+			}
+
+			result.Profile = profile
+			if target.Handle != "" {
+				id = profile.ID
+			}
+
+			if op == "follow" && !profile.CanFollow {
+				fail(op, ErrCannotFollow)
+				results[i] = result
+				return
+			}
+		}
+
+		if !opts.DryRun {
+			if err := act(ctx, id); err != nil {
+				fail(op, err)
+			}
+		}
+
+		results[i] = result
+	})
+
+	return results
+}
@@ -0,0 +1,121 @@
+package primfeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBulkServer(followed *[]string) *httptest.Server {
+	var mu sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/friend":
+			fmt.Fprint(w, `{"id": "10", "handle": "friend", "canFollow": true}`)
+		case "/entity/store":
+			fmt.Fprint(w, `{"id": "11", "handle": "store", "canFollow": false}`)
+		case "/entity/12":
+			fmt.Fprint(w, `{"id": "12", "handle": "pal", "canFollow": true}`)
+		case "/entity/13":
+			fmt.Fprint(w, `{"id": "13", "handle": "shop", "canFollow": false}`)
+		case "/follow/10", "/follow/12":
+			mu.Lock()
+			*followed = append(*followed, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBulkFollow(t *testing.T) {
+	// Arrange
+	var followed []string
+	mockServer := newBulkServer(&followed)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithMaxConcurrency(2))
+	targets := []Target{ByHandle("friend"), ByHandle("store"), ByHandle("ghost"), ByID("12"), ByID("13")}
+
+	// Act
+	results := pf.BulkFollow(context.Background(), targets, BulkOptions{})
+
+	// Assert
+	assert.Len(t, results, 5)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "10", results[0].Profile.ID)
+	assert.ErrorIs(t, results[1].Err, ErrCannotFollow)
+	assert.ErrorIs(t, results[2].Err, ErrNotFound)
+	assert.NoError(t, results[3].Err)
+	assert.Equal(t, "pal", results[3].Profile.Handle)
+	assert.ErrorIs(t, results[4].Err, ErrCannotFollow)
+
+	var bulkErr *BulkError
+	assert.True(t, errors.As(results[2].Err, &bulkErr))
+	assert.Equal(t, "resolve", bulkErr.Op)
+	assert.Equal(t, "ghost", bulkErr.Target.Handle)
+
+	assert.ElementsMatch(t, []string{"POST /follow/10", "POST /follow/12"}, followed)
+}
+
+func TestBulkUnfollowDryRun(t *testing.T) {
+	// Arrange
+	var followed []string
+	mockServer := newBulkServer(&followed)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	targets := []Target{ByHandle("friend"), ByHandle("store"), ByID("13"), ByID("14")}
+	results := pf.BulkUnfollow(context.Background(), targets, BulkOptions{DryRun: true})
+
+	// Assert
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.True(t, results[0].DryRun)
+	assert.Equal(t, "11", results[1].Profile.ID)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, "shop", results[2].Profile.Handle)
+	assert.ErrorIs(t, results[3].Err, ErrNotFound)
+	assert.Empty(t, followed)
+}
+
+func TestBulkUnfollowRetriesStaleHandle(t *testing.T) {
+	// Arrange
+	var followed []string
+	mockServer := newBulkServer(&followed)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	pf.HandleCache().Put("friend", User{ID: "99", Handle: "friend"})
+
+	// Act
+	results := pf.BulkUnfollow(context.Background(), []Target{ByHandle("friend")}, BulkOptions{})
+
+	// Assert
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "10", results[0].Profile.ID)
+	assert.Equal(t, []string{"DELETE /follow/10"}, followed)
+}
+
+func TestBulkDryRunSkipsHandleCache(t *testing.T) {
+	// Arrange
+	var followed []string
+	mockServer := newBulkServer(&followed)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	pf.HandleCache().Put("ghost", User{ID: "99", Handle: "ghost"})
+
+	// Act
+	results := pf.BulkUnfollow(context.Background(), []Target{ByHandle("ghost"), {}}, BulkOptions{DryRun: true})
+
+	// Assert
+	assert.ErrorIs(t, results[0].Err, ErrNotFound)
+	assert.ErrorIs(t, results[1].Err, ErrEmptyTarget)
+	assert.Empty(t, followed)
+}
@@ -0,0 +1,319 @@
+package primfeed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a GET response kept by the response cache.
+type CachedResponse struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+
+	// FreshUntil is when the response has to be revalidated with the
+	// server before it can be reused.
+	FreshUntil time.Time `json:"freshUntil"`
+}
+
+// CacheStore holds cached responses. Implementations must be safe for
+// concurrent use. Errors from Set and Delete never fail a request; the
+// response is simply not cached.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse) error
+	Delete(key string) error
+}
+
+// WithCache caches GET responses in store, honouring Cache-Control and
+// revalidating with ETag and Last-Modified. Responses are cached per
+// token, so switching accounts never serves another account's data. A
+// successful write drops the cached responses for its URL and parent paths.
+// /me, profiles, follow lists and notifications are never cached.
+func WithCache(store CacheStore) Option {
+	return func(p *Primfeed) {
+		p.cache = store
+	}
+}
+
+type noCacheKey struct{}
+
+// WithoutCache returns a context that makes requests skip the response
+// cache, neither reading from nor writing to it.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// fetch sends c through the response cache when it is enabled.
+func (p *Primfeed) fetch(ctx context.Context, c *call) ([]byte, error) {
+	if p.cache != nil && c.method != http.MethodGet {
+		body, err := p.do(ctx, c)
+		if err == nil {
+			p.invalidate(c.url)
+		}
+
+		return body, err
+	}
+
+	// /me carries the token, so it is never written to a store.
+	if p.cache == nil || ctx.Value(noCacheKey{}) != nil || c.url == p.BaseURL+"/me" {
+		return p.do(ctx, c)
+	}
+
+	key := p.cacheKey(c.url)
+	cached, ok := p.cache.Get(key)
+	if ok && time.Now().Before(cached.FreshUntil) {
+		return cached.Body, nil
+	}
+
+	if ok {
+		headers := maps.Clone(c.headers)
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+
+		if cached.ETag != "" {
+			headers["If-None-Match"] = cached.ETag
+		}
+		if cached.LastModified != "" {
+			headers["If-Modified-Since"] = cached.LastModified
+		}
+
+		c.headers = headers
+		c.revalidating = true
+	}
+
+	body, err := p.do(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if c.status == http.StatusNotModified {
+		if freshUntil, store := freshness(c.header, now); store {
+			cached.FreshUntil = freshUntil
+			if etag := c.header.Get("ETag"); etag != "" {
+				cached.ETag = etag
+			}
+
+			p.cache.Set(key, cached)
+		}
+
+		return cached.Body, nil
+	}
+
+	entry := &CachedResponse{
+		Body:         body,
+		ETag:         c.header.Get("ETag"),
+		LastModified: c.header.Get("Last-Modified"),
+		StoredAt:     now,
+	}
+
+	freshUntil, store := freshness(c.header, now)
+	if store && (freshUntil.After(now) || entry.ETag != "" || entry.LastModified != "") {
+		entry.FreshUntil = freshUntil
+		p.cache.Set(key, entry)
+	} else {
+		p.cache.Delete(key)
+	}
+
+	return body, nil
+}
+
+// invalidate drops the cached GETs a write to url may have changed: url
+// itself and, without the query string, every parent path under BaseURL.
+// Writes that change other resources, like following someone, aren't
+// covered, so /me, profiles, follow lists and notifications always skip
+// the cache.
+func (p *Primfeed) invalidate(url string) {
+	p.cache.Delete(p.cacheKey(url))
+
+	url, _, _ = strings.Cut(url, "?")
+	for strings.HasPrefix(url, p.BaseURL+"/") {
+		p.cache.Delete(p.cacheKey(url))
+		url = url[:strings.LastIndex(url, "/")]
+	}
+}
+
+// cacheKey keys responses by URL and by a hash of the token.
+func (p *Primfeed) cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(p.CurrentToken()))
+	return url + " " + hex.EncodeToString(sum[:8])
+}
+
+// freshness reads Cache-Control and Expires. It reports whether the
+// response may be stored at all, and until when it can be reused without
+// asking the server.
+func freshness(header http.Header, now time.Time) (time.Time, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(strings.ToLower(directive)), "=")
+
+		switch name {
+		case "no-store":
+			return time.Time{}, false
+		case "no-cache":
+			return now, true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+		}
+	}
+
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires, true
+	}
+
+	return now, true
+}
+
+// MemoryCacheStore keeps responses in memory, dropping the oldest once it
+// holds more than its limit.
+type MemoryCacheStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*CachedResponse
+}
+
+// NewMemoryCacheStore holds up to maxEntries responses; 0 means no limit.
+func NewMemoryCacheStore(maxEntries int) *MemoryCacheStore {
+	return &MemoryCacheStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*CachedResponse),
+	}
+}
+
+func (s *MemoryCacheStore) Get(key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	copied := *resp
+	return &copied, true
+}
+
+func (s *MemoryCacheStore) Set(key string, resp *CachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *resp
+	s.entries[key] = &copied
+
+	for s.maxEntries > 0 && len(s.entries) > s.maxEntries {
+		var oldest string
+		for k, v := range s.entries {
+			if oldest == "" || v.StoredAt.Before(s.entries[oldest].StoredAt) {
+				oldest = k
+			}
+		}
+
+		delete(s.entries, oldest)
+	}
+
+	return nil
+}
+
+func (s *MemoryCacheStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// DiskCacheStore keeps one JSON file per response in Dir, readable only
+// by the owner. The files hold full response bodies, one set per token,
+// and are never removed on their own; call Prune now and then.
+type DiskCacheStore struct {
+	Dir string
+}
+
+func NewDiskCacheStore(dir string) (*DiskCacheStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &DiskCacheStore{Dir: dir}, nil
+}
+
+func (s *DiskCacheStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s *DiskCacheStore) Get(key string) (*CachedResponse, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+
+	return &resp, true
+}
+
+func (s *DiskCacheStore) Set(key string, resp *CachedResponse) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path(key), data)
+}
+
+func (s *DiskCacheStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// Prune removes the responses stored more than maxAge ago, along with any
+// file that can't be read back.
+func (s *DiskCacheStore) Prune(maxAge time.Duration) error {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var resp CachedResponse
+		if json.Unmarshal(data, &resp) == nil && !resp.StoredAt.Before(cutoff) {
+			continue
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
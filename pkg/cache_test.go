@@ -0,0 +1,224 @@
+package primfeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheMaxAge(t *testing.T) {
+	// Arrange
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "private, max-age=60")
+		fmt.Fprintf(w, `{"data": {"id": "p%d"}}`, calls)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithCache(NewMemoryCacheStore(0)))
+	ctx := context.Background()
+
+	// Act
+	first, _ := pf.GetPost(ctx, "p1")
+	second, _ := pf.GetPost(ctx, "p1")
+	bypassed, _ := pf.GetPost(WithoutCache(ctx), "p1")
+	pf.SetToken("another-account")
+	otherToken, _ := pf.GetPost(ctx, "p1")
+
+	// Assert
+	assert.Equal(t, "p1", first.Data.ID)
+	assert.Equal(t, "p1", second.Data.ID)
+	assert.Equal(t, "p2", bypassed.Data.ID)
+	assert.Equal(t, "p3", otherToken.Data.ID)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	// Arrange
+	var calls, notModified int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		fmt.Fprint(w, `{"feed": [{"data": {"id": "p1"}}]}`)
+	}))
+	defer mockServer.Close()
+
+	store, err := NewDiskCacheStore(t.TempDir())
+	assert.NoError(t, err)
+	pf := NewPrimfeed(mockServer.URL, WithCache(store))
+
+	// Act
+	first, firstErr := pf.GetFeed("testuser", 1)
+	second, secondErr := pf.GetFeed("testuser", 1)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, first, second)
+	assert.Equal(t, "p1", second.Feed[0].Data.ID)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, notModified)
+}
+
+func TestUnexpectedNotModified(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer mockServer.Close()
+	store := NewMemoryCacheStore(0)
+	uncached := NewPrimfeed(mockServer.URL)
+	cached := NewPrimfeed(mockServer.URL, WithCache(store))
+	headers := map[string]string{"If-None-Match": `"v1"`}
+
+	// Act
+	_, uncachedErr := uncached.GetUserProfile("testuser")
+	var post Feed
+	cachedErr := cached.RequestContext(context.Background(), "GET", mockServer.URL+"/pf/post/p1", nil, headers, &post)
+
+	// Assert
+	var apiErr *APIError
+	assert.True(t, errors.As(uncachedErr, &apiErr))
+	assert.Equal(t, http.StatusNotModified, apiErr.StatusCode)
+	assert.True(t, errors.As(cachedErr, &apiErr))
+	assert.Empty(t, store.entries)
+}
+
+func TestCacheNoStore(t *testing.T) {
+	// Arrange
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"data": {"id": "p1"}}`)
+	}))
+	defer mockServer.Close()
+	store := NewMemoryCacheStore(0)
+	pf := NewPrimfeed(mockServer.URL, WithCache(store))
+	ctx := context.Background()
+
+	// Act
+	pf.GetPost(ctx, "p1")
+	pf.GetPost(ctx, "p1")
+
+	// Assert
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, store.entries)
+}
+
+func TestCacheSkipsRelationships(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	followed := false
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Cache-Control", "max-age=300")
+		switch r.URL.Path {
+		case "/me":
+			fmt.Fprint(w, `{"user": {"id": "1", "handle": "me"}}`)
+		case "/entity/me/followers":
+			fmt.Fprint(w, `[]`)
+		case "/entity/me/followed":
+			if followed {
+				fmt.Fprint(w, `[{"id": "9", "handle": "friend"}]`)
+				return
+			}
+			fmt.Fprint(w, `[]`)
+		case "/follow/9":
+			followed = true
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithCache(NewMemoryCacheStore(0)))
+	assert.NoError(t, pf.GetMe())
+
+	// Act
+	assert.NoError(t, pf.FollowById("9"))
+	err := pf.GetMe()
+
+	// Assert
+	assert.NoError(t, err)
+	following := pf.Snapshot().Following
+	assert.Len(t, following, 1)
+	assert.True(t, pf.MyRelationships().IsFollowing("friend"))
+}
+
+func TestDiskCacheStorePrune(t *testing.T) {
+	// Arrange
+	store, err := NewDiskCacheStore(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, store.Set("old", &CachedResponse{Body: []byte("1"), StoredAt: time.Now().Add(-2 * time.Hour)}))
+	assert.NoError(t, store.Set("new", &CachedResponse{Body: []byte("2"), StoredAt: time.Now()}))
+
+	// Act
+	err = store.Prune(time.Hour)
+
+	// Assert
+	assert.NoError(t, err)
+	_, oldOK := store.Get("old")
+	_, newOK := store.Get("new")
+	assert.False(t, oldOK)
+	assert.True(t, newOK)
+}
+
+func TestCacheSkipsMe(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=300")
+		fmt.Fprint(w, `{"token": "secret", "user": {"id": "1", "handle": "me"}}`)
+	}))
+	defer mockServer.Close()
+	store := NewMemoryCacheStore(0)
+	pf := NewPrimfeed(mockServer.URL, WithCache(store))
+
+	// Act
+	var profile Profile
+	err := pf.RequestContext(context.Background(), "GET", mockServer.URL+"/me", nil, nil, &profile)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, store.entries)
+}
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	header := func(key string, value string) http.Header {
+		h := make(http.Header)
+		h.Set(key, value)
+		return h
+	}
+
+	until, store := freshness(header("Cache-Control", "public, max-age=30"), now)
+	assert.True(t, store)
+	assert.Equal(t, now.Add(30*time.Second), until)
+
+	until, store = freshness(header("Expires", now.Add(time.Hour).Format(http.TimeFormat)), now)
+	assert.True(t, store)
+	assert.Equal(t, now.Add(time.Hour), until)
+
+	until, store = freshness(header("Cache-Control", "no-cache"), now)
+	assert.True(t, store)
+	assert.Equal(t, now, until)
+
+	_, store = freshness(header("Cache-Control", "no-store"), now)
+	assert.False(t, store)
+}
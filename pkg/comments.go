@@ -0,0 +1,99 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+type Comment struct {
+	ID           string `json:"id"`
+	ParentID     string `json:"parentId,omitempty"`
+	Owner        User   `json:"owner"`
+	Content      string `json:"content"`
+	CreatedAt    int    `json:"createdAt,omitempty"`
+	Likes        int    `json:"likes,omitempty"`
+	Liked        bool   `json:"liked,omitempty"`
+	RepliesCount int    `json:"repliesCount,omitempty"`
+	Perms        Perms  `json:"perms,omitempty"`
+}
+
+type CommentsResponse struct {
+	Comments []Comment `json:"comments"`
+}
+
+type commentInput struct {
+	Content  string `json:"content"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+func (p *Primfeed) GetComments(ctx context.Context, postID string, page int) (CommentsResponse, error) {
+	url := fmt.Sprintf("%s/pf/post/%s/comments?page=%d", p.BaseURL, postID, page)
+
+	var commentsResponse CommentsResponse
+
+	err := p.RequestContext(ctx, "GET", url, nil, nil, &commentsResponse)
+	if err != nil {
+		return CommentsResponse{}, fmt.Errorf("could not load comments: %w", err)
+	}
+
+	return commentsResponse, nil
+}
+
+// CommentPager pages through the comments of a post.
+func (p *Primfeed) CommentPager(ctx context.Context, postID string, opts ...PagerOption) *Pager[Comment] {
+	fetch := func(ctx context.Context, page int) ([]Comment, error) {
+		resp, err := p.GetComments(ctx, postID, page)
+		return resp.Comments, err
+	}
+
+	return newPager(ctx, fetch, func(c Comment) string { return c.ID }, opts)
+}
+
+// CommentIter iterates over the comments of a post, page after page.
+func (p *Primfeed) CommentIter(ctx context.Context, postID string, opts ...PagerOption) iter.Seq2[Comment, error] {
+	return p.CommentPager(ctx, postID, opts...).All()
+}
+
+func (p *Primfeed) AddComment(ctx context.Context, postID string, content string) (Comment, error) {
+	return p.postComment(ctx, postID, commentInput{Content: content})
+}
+
+func (p *Primfeed) ReplyToComment(ctx context.Context, postID string, commentID string, content string) (Comment, error) {
+	return p.postComment(ctx, postID, commentInput{Content: content, ParentID: commentID})
+}
+
+func (p *Primfeed) postComment(ctx context.Context, postID string, input commentInput) (Comment, error) {
+	url := fmt.Sprintf("%s/pf/post/%s/comments", p.BaseURL, postID)
+
+	var comment Comment
+	err := p.RequestContext(ctx, "POST", url, input, nil, &comment)
+	if err != nil {
+		return Comment{}, fmt.Errorf("could not add comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (p *Primfeed) DeleteComment(ctx context.Context, commentID string) error {
+	url := fmt.Sprintf("%s/pf/comment/%s", p.BaseURL, commentID)
+
+	err := p.RequestContext(ctx, "DELETE", url, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// LikeComment toggles our like on a comment, like Like does for posts.
+func (p *Primfeed) LikeComment(ctx context.Context, commentID string) error {
+	url := fmt.Sprintf("%s/pf/comment/%s/like", p.BaseURL, commentID)
+
+	err := p.RequestContext(ctx, "POST", url, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not like comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package primfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentIter(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pf/post/p1/comments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"comments": [{"id": "c1", "content": "first"}, {"id": "c2"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"comments": [{"id": "c3", "parentId": "c1"}]}`)
+		default:
+			fmt.Fprint(w, `{"comments": []}`)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	var comments []Comment
+	for comment, err := range pf.CommentIter(context.Background(), "p1") {
+		assert.NoError(t, err)
+		comments = append(comments, comment)
+	}
+
+	// Assert
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "first", comments[0].Content)
+	assert.Equal(t, "c1", comments[2].ParentID)
+}
+
+func TestReplyToComment(t *testing.T) {
+	// Arrange
+	var input commentInput
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pf/post/p1/comments" && r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&input)
+			fmt.Fprintf(w, `{"id": "c9", "parentId": %q, "content": %q}`, input.ParentID, input.Content)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	reply, err := pf.ReplyToComment(context.Background(), "p1", "c1", "thanks!")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "c9", reply.ID)
+	assert.Equal(t, "c1", reply.ParentID)
+	assert.Equal(t, "thanks!", reply.Content)
+}
@@ -0,0 +1,95 @@
+package primfeed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newConcurrencyServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me":
+			fmt.Fprint(w, `{"user": {"id": "1", "handle": "testuser"}}`)
+		case "/entity/testuser/followers", "/entity/testuser/followed":
+			fmt.Fprint(w, `[{"id": "123", "handle": "othertestuser"}]`)
+		case "/notifications":
+			fmt.Fprint(w, `{"unreadCount": 1, "notifications": [{"type": "follow", "notifications": [{"id": "n1"}]}]}`)
+		case "/login/create-inworld-request":
+			fmt.Fprint(w, `{"requestId": "r1"}`)
+		case "/entity/testuser":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestConcurrentUse(t *testing.T) {
+	// Arrange
+	mockServer := newConcurrencyServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	assert.NoError(t, pf.GetMe())
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(6)
+		go func() {
+			defer wg.Done()
+			pf.SetToken(fmt.Sprintf("token-%d", i))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pf.GetMe())
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := pf.GetNotifications()
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := pf.GetLoginCode("testuser")
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pf.UpdateProfile(MyProfile{About: "hello"}))
+		}()
+		go func() {
+			defer wg.Done()
+			me := pf.Snapshot()
+			_ = len(me.Followers) + len(me.Notifications.Notifications)
+			_ = pf.CurrentToken()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	me := pf.Snapshot()
+	assert.Equal(t, "testuser", me.Profile.User.Handle)
+	assert.Len(t, me.Followers, 1)
+	assert.Len(t, me.Following, 1)
+	assert.Equal(t, 1, me.Notifications.UnreadCount)
+}
+
+func TestSnapshotIsIsolated(t *testing.T) {
+	// Arrange
+	mockServer := newConcurrencyServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	assert.NoError(t, pf.GetMe())
+
+	// Act
+	me := pf.Snapshot()
+	me.Followers[0].Handle = "changed"
+
+	// Assert
+	assert.Equal(t, "othertestuser", pf.Snapshot().Followers[0].Handle)
+}
@@ -0,0 +1,132 @@
+package primfeed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+
+	// ErrMediaTooLarge is returned by UploadMedia for files over the
+	// account's upload limit.
+	ErrMediaTooLarge = errors.New("media exceeds upload limit")
+)
+
+// APIError is returned when the API answers with a non 2xx status. Use
+// errors.As to inspect it, or errors.Is with one of the sentinel errors
+// to test for the common cases.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+	Body       []byte
+
+	// Message is the "error" (or "message") field of the response body,
+	// when the API sent one.
+	Message string
+
+	// RetryAfter is how long the server asked us to wait, taken from the
+	// Retry-After header. Zero when the header was absent.
+	RetryAfter time.Duration
+
+	kind error
+}
+
+func newAPIError(method string, url string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Method:     method,
+		URL:        url,
+		Body:       body,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	var payload struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Message = payload.Error
+		if apiErr.Message == "" {
+			apiErr.Message = payload.Message
+		}
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed to fetch data: %s %s: %s", e.Method, e.URL, e.Status)
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+
+	return msg
+}
+
+func (e *APIError) Is(target error) bool {
+	if e.kind != nil {
+		return target == e.kind
+	}
+
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+
+	return false
+}
+
+// parseRetryAfter understands both forms of the header: a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if when, err := http.ParseTime(value); err == nil && when.After(now) {
+		return when.Sub(now)
+	}
+
+	return 0
+}
+
+// newLoginError covers the login endpoints answering 200 with an "error"
+// field instead of a failing status.
+func newLoginError(url string, message string) *APIError {
+	return &APIError{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Method:     "POST",
+		URL:        url,
+		Message:    message,
+		kind:       ErrUnauthorized,
+	}
+}
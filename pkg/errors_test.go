@@ -0,0 +1,65 @@
+package primfeed
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":"slow down"}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	_, err := pf.GetUserProfile("testuser")
+
+	// Assert
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.False(t, errors.Is(err, ErrUnauthorized))
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Equal(t, "GET", apiErr.Method)
+	assert.Equal(t, mockServer.URL+"/entity/testuser", apiErr.URL)
+	assert.Equal(t, "slow down", apiErr.Message)
+	assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+}
+
+func TestLoginErrorField(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error":"Invalid credentials"}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	_, err := pf.Login("username", "wrong", nil)
+
+	// Assert
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+	assert.Equal(t, "Invalid credentials", apiErr.Message)
+	assert.Empty(t, pf.Token)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, time.Minute, parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
@@ -0,0 +1,87 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// GetUserFollowersPage loads one page of the followers of username. Like
+// every follow list, it skips the response cache.
+func (p *Primfeed) GetUserFollowersPage(ctx context.Context, username string, page int) (Followers, error) {
+	url := fmt.Sprintf("%s/entity/%s/followers?page=%d", p.BaseURL, username, page)
+	var followers Followers
+
+	if err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &followers); err != nil {
+		return nil, err
+	}
+
+	return followers, nil
+}
+
+// GetUserFollowsPage loads one page of the entities username follows,
+// skipping the response cache.
+func (p *Primfeed) GetUserFollowsPage(ctx context.Context, username string, page int) (Followers, error) {
+	url := fmt.Sprintf("%s/entity/%s/followed?page=%d", p.BaseURL, username, page)
+	var following Followers
+
+	if err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &following); err != nil {
+		return nil, err
+	}
+
+	return following, nil
+}
+
+// FollowersPager pages through the followers of username.
+func (p *Primfeed) FollowersPager(ctx context.Context, username string, opts ...PagerOption) *Pager[Follower] {
+	fetch := func(ctx context.Context, page int) ([]Follower, error) {
+		return p.GetUserFollowersPage(ctx, username, page)
+	}
+
+	return newPager(ctx, fetch, followerKey, opts)
+}
+
+// FollowsPager pages through the entities username follows.
+func (p *Primfeed) FollowsPager(ctx context.Context, username string, opts ...PagerOption) *Pager[Follower] {
+	fetch := func(ctx context.Context, page int) ([]Follower, error) {
+		return p.GetUserFollowsPage(ctx, username, page)
+	}
+
+	return newPager(ctx, fetch, followerKey, opts)
+}
+
+func (p *Primfeed) FollowersIter(ctx context.Context, username string, opts ...PagerOption) iter.Seq2[Follower, error] {
+	return p.FollowersPager(ctx, username, opts...).All()
+}
+
+func (p *Primfeed) FollowsIter(ctx context.Context, username string, opts ...PagerOption) iter.Seq2[Follower, error] {
+	return p.FollowsPager(ctx, username, opts...).All()
+}
+
+// GetAllFollowers walks every page of followers. Pass OnPage to report
+// progress on large accounts.
+func (p *Primfeed) GetAllFollowers(ctx context.Context, username string, opts ...PagerOption) (Followers, error) {
+	return collect(p.FollowersPager(ctx, username, opts...))
+}
+
+// GetAllFollows walks every page of the entities username follows.
+func (p *Primfeed) GetAllFollows(ctx context.Context, username string, opts ...PagerOption) (Followers, error) {
+	return collect(p.FollowsPager(ctx, username, opts...))
+}
+
+func followerKey(f Follower) string {
+	return f.ID
+}
+
+func collect(pager *Pager[Follower]) (Followers, error) {
+	var all Followers
+	for pager.Next() {
+		all = append(all, pager.Item())
+	}
+
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
@@ -0,0 +1,70 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllFollowers(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/entity/testuser/followers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `[{"id": "1", "handle": "one"}, {"id": "2", "handle": "two"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id": "2", "handle": "two"}, {"id": "3", "handle": "three"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	var pages, items []int
+	progress := func(page int, n int) {
+		pages = append(pages, page)
+		items = append(items, n)
+	}
+
+	// Act
+	followers, err := pf.GetAllFollowers(context.Background(), "testuser", OnPage(progress))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, followers, 3)
+	assert.Equal(t, "three", followers[2].Handle)
+	assert.Equal(t, []int{1, 2, 3}, pages)
+	assert.Equal(t, []int{2, 3, 3}, items)
+}
+
+func TestFollowsIgnoringPagination(t *testing.T) {
+	// Arrange
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[{"id": "1", "handle": "one"}, {"id": "2", "handle": "two"}]`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	var handles []string
+	for f, err := range pf.FollowsIter(context.Background(), "testuser") {
+		assert.NoError(t, err)
+		handles = append(handles, f.Handle)
+	}
+
+	// Assert
+	assert.Equal(t, []string{"one", "two"}, handles)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,255 @@
+package primfeed
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHandleCacheSize = 1024
+	defaultHandleCacheTTL  = time.Hour
+)
+
+// HandleCache is an LRU cache of handle to User lookups with a TTL, so
+// methods that take a handle but need an ID don't fetch the profile every
+// time. Handles are matched case-insensitively. It is safe for concurrent
+// use.
+type HandleCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type handleEntry struct {
+	Handle  string    `json:"handle"`
+	User    User      `json:"user"`
+	Expires time.Time `json:"expires"`
+}
+
+// HandleCacheStats counts lookups since the cache was created.
+type HandleCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+func NewHandleCache(capacity int, ttl time.Duration) *HandleCache {
+	if capacity <= 0 {
+		capacity = defaultHandleCacheSize
+	}
+
+	return &HandleCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// WithHandleCache replaces the default handle cache. Pass nil to turn
+// caching off.
+func WithHandleCache(c *HandleCache) Option {
+	return func(p *Primfeed) {
+		p.handles = c
+	}
+}
+
+// HandleCache returns the client's handle cache, nil when disabled.
+func (p *Primfeed) HandleCache() *HandleCache {
+	return p.handles
+}
+
+func (c *HandleCache) Get(handle string) (User, bool) {
+	if c == nil {
+		return User{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := strings.ToLower(handle)
+	el, ok := c.entries[key]
+	if ok && c.ttl > 0 && time.Now().After(el.Value.(*handleEntry).Expires) {
+		c.remove(el)
+		ok = false
+	}
+
+	if !ok {
+		c.misses++
+		return User{}, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(el)
+
+	return el.Value.(*handleEntry).User, true
+}
+
+func (c *HandleCache) Put(handle string, user User) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.put(&handleEntry{Handle: strings.ToLower(handle), User: user, Expires: time.Now().Add(c.ttl)})
+}
+
+func (c *HandleCache) put(entry *handleEntry) {
+	if el, ok := c.entries[entry.Handle]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[entry.Handle] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *HandleCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*handleEntry).Handle)
+}
+
+// Invalidate drops handle from the cache, e.g. after the entity renamed.
+func (c *HandleCache) Invalidate(handle string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[strings.ToLower(handle)]; ok {
+		c.remove(el)
+	}
+}
+
+// Clear drops every entry but keeps the stats.
+func (c *HandleCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *HandleCache) Stats() HandleCacheStats {
+	if c == nil {
+		return HandleCacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return HandleCacheStats{Hits: c.hits, Misses: c.misses, Size: c.order.Len()}
+}
+
+// Save writes the cache to path so it survives restarts, see Load.
+func (c *HandleCache) Save(path string) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	entries := make([]*handleEntry, 0, c.order.Len())
+	for el := c.order.Back(); el != nil; el = el.Prev() {
+		entries = append(entries, el.Value.(*handleEntry))
+	}
+	c.mu.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// Load adds the entries saved at path, skipping expired ones.
+func (c *HandleCache) Load(path string) error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var entries []*handleEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for _, entry := range entries {
+		if c.ttl > 0 && now.After(entry.Expires) {
+			continue
+		}
+
+		c.put(entry)
+	}
+
+	return nil
+}
+
+// resolveHandle returns the User behind handle, from the cache when
+// possible. cached reports whether it came from the cache.
+func (p *Primfeed) resolveHandle(ctx context.Context, handle string) (user User, cached bool, err error) {
+	if user, ok := p.handles.Get(handle); ok {
+		return user, true, nil
+	}
+
+	profile, err := p.GetUserProfileContext(ctx, handle)
+	if err != nil {
+		return User{}, false, err
+	}
+
+	return profile.User, false, nil
+}
+
+// actOnHandle resolves handle and calls act with its ID. A not found from
+// act for a cached ID may mean the ID is stale, so the handle is looked up
+// again and act retried once. resolved is false when the handle itself
+// couldn't be looked up.
+func (p *Primfeed) actOnHandle(ctx context.Context, handle string, act func(ctx context.Context, id string) error) (user User, resolved bool, err error) {
+	user, cached, err := p.resolveHandle(ctx, handle)
+	if err != nil {
+		return User{}, false, err
+	}
+
+	err = act(ctx, user.ID)
+	if !cached || !errors.Is(err, ErrNotFound) {
+		return user, true, err
+	}
+
+	p.handles.Invalidate(handle)
+	user, _, err = p.resolveHandle(ctx, handle)
+	if err != nil {
+		return User{}, false, err
+	}
+
+	return user, true, act(ctx, user.ID)
+}
@@ -0,0 +1,141 @@
+package primfeed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleCacheLRU(t *testing.T) {
+	// Arrange
+	cache := NewHandleCache(2, time.Hour)
+
+	// Act
+	cache.Put("One", User{ID: "1"})
+	cache.Put("two", User{ID: "2"})
+	_, okOne := cache.Get("one")
+	cache.Put("three", User{ID: "3"})
+	_, okTwo := cache.Get("two")
+	three, okThree := cache.Get("THREE")
+
+	// Assert
+	assert.True(t, okOne)
+	assert.False(t, okTwo)
+	assert.True(t, okThree)
+	assert.Equal(t, "3", three.ID)
+	assert.Equal(t, HandleCacheStats{Hits: 2, Misses: 1, Size: 2}, cache.Stats())
+}
+
+func TestHandleCacheTTLAndPersistence(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "handles.json")
+	cache := NewHandleCache(10, time.Hour)
+	cache.Put("fresh", User{ID: "1"})
+	cache.put(&handleEntry{Handle: "stale", User: User{ID: "2"}, Expires: time.Now().Add(-time.Minute)})
+	assert.NoError(t, cache.Save(path))
+
+	// Act
+	loaded := NewHandleCache(10, time.Hour)
+	err := loaded.Load(path)
+
+	// Assert
+	assert.NoError(t, err)
+	user, ok := loaded.Get("fresh")
+	assert.True(t, ok)
+	assert.Equal(t, "1", user.ID)
+	_, ok = loaded.Get("stale")
+	assert.False(t, ok)
+	_, ok = cache.Get("stale")
+	assert.False(t, ok)
+}
+
+func TestFollowUserUsesHandleCache(t *testing.T) {
+	// Arrange
+	var profileCalls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/othertestuser":
+			profileCalls++
+			fmt.Fprint(w, `{"id": "123", "handle": "othertestuser"}`)
+		case "/follow/123":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	assert.NoError(t, pf.FollowUser("othertestuser"))
+	assert.NoError(t, pf.UnfollowUser("OtherTestUser"))
+	pf.HandleCache().Invalidate("othertestuser")
+	assert.NoError(t, pf.FollowUser("othertestuser"))
+
+	// Assert
+	assert.Equal(t, 2, profileCalls)
+	assert.Equal(t, uint64(1), pf.HandleCache().Stats().Hits)
+}
+
+func TestFollowUserRetriesStaleHandle(t *testing.T) {
+	// Arrange
+	var followed []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/othertestuser":
+			fmt.Fprint(w, `{"id": "456", "handle": "othertestuser"}`)
+		case "/follow/456":
+			followed = append(followed, r.Method)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	pf.HandleCache().Put("othertestuser", User{ID: "123", Handle: "othertestuser"})
+
+	// Act
+	followErr := pf.FollowUser("othertestuser")
+	pf.HandleCache().Put("othertestuser", User{ID: "123", Handle: "othertestuser"})
+	unfollowErr := pf.UnfollowUser("othertestuser")
+
+	// Assert
+	assert.NoError(t, followErr)
+	assert.NoError(t, unfollowErr)
+	assert.Equal(t, []string{"POST", "DELETE"}, followed)
+	user, ok := pf.HandleCache().Get("othertestuser")
+	assert.True(t, ok)
+	assert.Equal(t, "456", user.ID)
+}
+
+func TestFollowUserDoesNotRetryFreshHandle(t *testing.T) {
+	// Arrange
+	var profileCalls, followCalls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/othertestuser":
+			profileCalls++
+			fmt.Fprint(w, `{"id": "456", "handle": "othertestuser"}`)
+		case "/follow/456":
+			followCalls++
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	err := pf.FollowUser("othertestuser")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 1, profileCalls)
+	assert.Equal(t, 1, followCalls)
+}
@@ -0,0 +1,69 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+)
+
+// likeState is what the like endpoint answers with, when it answers with
+// anything at all.
+type likeState struct {
+	Liked *bool `json:"liked"`
+	Likes *int  `json:"likes"`
+}
+
+// SetLiked makes sure post is liked (or not) and returns its like count.
+// The like endpoint toggles, so the post's current state is loaded first
+// and the endpoint is only called when it differs from liked. That load
+// always skips the response cache, since a stale state would undo the like.
+func (p *Primfeed) SetLiked(ctx context.Context, post string, liked bool) (int, error) {
+	current, err := p.GetPost(WithoutCache(ctx), post)
+	if err != nil {
+		return 0, fmt.Errorf("could not like post: %w", err)
+	}
+
+	if current.Liked == liked {
+		return current.Likes, nil
+	}
+
+	state, err := p.toggleLike(ctx, post)
+	if err != nil {
+		return 0, fmt.Errorf("could not like post: %w", err)
+	}
+
+	if state.Liked != nil && *state.Liked != liked {
+		return 0, fmt.Errorf("could not like post %s: like state did not change", post)
+	}
+
+	if state.Likes != nil {
+		return *state.Likes, nil
+	}
+
+	if liked {
+		return current.Likes + 1, nil
+	}
+
+	return max(current.Likes-1, 0), nil
+}
+
+func (p *Primfeed) toggleLike(ctx context.Context, post string) (likeState, error) {
+	url := fmt.Sprintf("%s/pf/post/%s/like", p.BaseURL, post)
+
+	var state likeState
+	err := p.RequestContext(ctx, "POST", url, nil, nil, &state)
+
+	return state, err
+}
+
+// GetLikers lists the entities that liked post.
+func (p *Primfeed) GetLikers(ctx context.Context, post string) ([]User, error) {
+	url := fmt.Sprintf("%s/pf/post/%s/likes", p.BaseURL, post)
+
+	var likers []User
+	err := p.RequestContext(ctx, "GET", url, nil, nil, &likers)
+	if err != nil {
+		return nil, fmt.Errorf("could not get likers: %w", err)
+	}
+
+	return likers, nil
+}
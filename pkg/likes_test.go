@@ -0,0 +1,116 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newLikeServer serves a single post whose like endpoint toggles, like the
+// real API does.
+func newLikeServer() (*httptest.Server, *int) {
+	var mu sync.Mutex
+	liked, likes, toggles := false, 4, 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/pf/post/p1" && r.Method == "GET":
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, `{"liked": %t, "likes": %d, "data": {"id": "p1"}}`, liked, likes)
+		case r.URL.Path == "/pf/post/p1/like" && r.Method == "POST":
+			toggles++
+			liked = !liked
+			if liked {
+				likes++
+			} else {
+				likes--
+			}
+		case r.URL.Path == "/pf/post/p1/likes":
+			fmt.Fprint(w, `[{"id": "1", "handle": "testuser"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})), &toggles
+}
+
+func TestSetLiked(t *testing.T) {
+	// Arrange
+	mockServer, toggles := newLikeServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	ctx := context.Background()
+
+	// Act
+	first, firstErr := pf.SetLiked(ctx, "p1", true)
+	second, secondErr := pf.SetLiked(ctx, "p1", true)
+	unliked, unlikedErr := pf.SetLiked(ctx, "p1", false)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, unlikedErr)
+	assert.Equal(t, 5, first)
+	assert.Equal(t, 5, second)
+	assert.Equal(t, 4, unliked)
+	assert.Equal(t, 2, *toggles)
+}
+
+func TestSetLikedWithCache(t *testing.T) {
+	// Arrange
+	mockServer, toggles := newLikeServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithCache(NewMemoryCacheStore(0)))
+	ctx := context.Background()
+	before, err := pf.GetPost(ctx, "p1")
+	assert.NoError(t, err)
+
+	// Act
+	_, firstErr := pf.SetLiked(ctx, "p1", true)
+	_, secondErr := pf.SetLiked(ctx, "p1", true)
+	after, err := pf.GetPost(ctx, "p1")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *toggles)
+	assert.False(t, before.Liked)
+	assert.True(t, after.Liked)
+}
+
+func TestLikeIsIdempotent(t *testing.T) {
+	// Arrange
+	mockServer, toggles := newLikeServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	assert.NoError(t, pf.Like("p1"))
+	assert.NoError(t, pf.Like("p1"))
+
+	// Assert
+	assert.Equal(t, 1, *toggles)
+}
+
+func TestGetLikers(t *testing.T) {
+	// Arrange
+	mockServer, _ := newLikeServer()
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	likers, err := pf.GetLikers(context.Background(), "p1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, likers, 1)
+	assert.Equal(t, "testuser", likers[0].Handle)
+}
@@ -0,0 +1,89 @@
+package primfeed
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// WithLogger makes the client emit a debug event for every HTTP attempt
+// with its method, path, status, duration, attempt number and sizes.
+// Request and response bodies are never logged. The login and profile
+// types also redact their secrets if you log them yourself.
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *Primfeed) {
+		p.logger = logger
+	}
+}
+
+func (p *Primfeed) logAttempt(ctx context.Context, c *call, attempt int, status int, duration time.Duration, size int, err error) {
+	if p.logger == nil || !p.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", c.method),
+		slog.String("path", urlPath(c.url)),
+		slog.Int("status", status),
+		slog.Duration("duration", duration),
+		slog.Int("attempt", attempt),
+		slog.Int("bytes_out", len(c.payload)),
+		slog.Int("bytes_in", size),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	p.logger.LogAttrs(ctx, slog.LevelDebug, "primfeed request", attrs...)
+}
+
+func urlPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	return u.Path
+}
+
+func (r LoginRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", r.Username),
+		slog.String("password", redactedValue),
+		slog.Any("companyID", r.CompanyID),
+		slog.String("redirect", r.Redirect),
+	)
+}
+
+func (r LoginInworldCodeRequest) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("requestId", r.RequestID),
+		slog.String("username", r.Username),
+		slog.String("otp", redactedValue),
+		slog.String("companyID", r.CompanyID),
+		slog.String("redirect", r.Redirect),
+	)
+}
+
+func (r LoginResponse) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("user", r.User),
+		slog.String("selectedStore", r.SelectedStore),
+		slog.String("token", redactedValue),
+		slog.String("redirect", r.Redirect),
+		slog.String("error", r.Error),
+	)
+}
+
+// LogValue keeps the token /me sends back out of the logs.
+func (p Profile) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("version", p.Version),
+		slog.Any("subscription", p.Subscription),
+		slog.Any("selectedEntity", p.SelectedEntity),
+		slog.Any("user", p.User),
+		slog.String("token", redactedValue),
+	)
+}
@@ -0,0 +1,51 @@
+package primfeed
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRedactsCredentials(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		switch r.URL.Path {
+		case "/login", "/login/inworld-code":
+			fmt.Fprint(w, `{"user":"testuser", "token":"0123456789abcdef"}`)
+		case "/me":
+			fmt.Fprint(w, `{"user": {"handle": "testuser"}, "token":"0123456789abcdef"}`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer mockServer.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	pf := NewPrimfeed(mockServer.URL, WithLogger(logger))
+
+	// Act
+	_, loginErr := pf.Login("testuser", "hunter22", nil)
+	_, codeErr := pf.LoginWithCode("r1", "987654", "")
+	meErr := pf.GetMe()
+
+	// Assert
+	assert.NoError(t, loginErr)
+	assert.NoError(t, codeErr)
+	assert.NoError(t, meErr)
+	assert.NotContains(t, logs.String(), "hunter22")
+	assert.NotContains(t, logs.String(), "987654")
+	assert.NotContains(t, logs.String(), "0123456789abcdef")
+	assert.Contains(t, logs.String(), `"path":"/login"`)
+	assert.Contains(t, logs.String(), `"status":200`)
+	assert.Contains(t, logs.String(), `"attempt":1`)
+	assert.NotContains(t, logs.String(), `"request"`)
+	assert.NotContains(t, logs.String(), `"response"`)
+}
@@ -0,0 +1,232 @@
+package primfeed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is how much of an upload is buffered to detect its type and
+// dimensions. It is large enough to skip past a full EXIF block in JPEGs.
+const sniffLen = 128 << 10
+
+type uploadConfig struct {
+	progress func(sent int64, total int64)
+}
+
+// UploadOption configures UploadMedia.
+type UploadOption func(*uploadConfig)
+
+// OnProgress calls fn as the upload is streamed. total is -1 when the size
+// of the reader isn't known up front.
+func OnProgress(fn func(sent int64, total int64)) UploadOption {
+	return func(c *uploadConfig) {
+		c.progress = fn
+	}
+}
+
+// UploadMedia streams r to the API as a multipart upload and returns the
+// stored Media, ready to be referenced from PostInput.Media.
+//
+// The size is checked against the account's subscription limit before
+// anything is sent when r reports its length (*os.File, *bytes.Reader,
+// ...), and enforced while streaming otherwise; either way the error
+// wraps ErrMediaTooLarge.
+func (p *Primfeed) UploadMedia(ctx context.Context, r io.Reader, filename string, opts ...UploadOption) (Media, error) {
+	var cfg uploadConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	limit, err := p.uploadLimit(ctx)
+	if err != nil {
+		return Media{}, fmt.Errorf("could not upload media: %w", err)
+	}
+
+	total := readerSize(r)
+	if limit > 0 && total > limit {
+		return Media{}, fmt.Errorf("could not upload %s (%d bytes, limit %d): %w", filename, total, limit, ErrMediaTooLarge)
+	}
+
+	br := bufio.NewReaderSize(r, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return Media{}, fmt.Errorf("could not read media: %w", err)
+	}
+
+	contentType := detectContentType(head, filename)
+
+	var width, height int
+	if imgCfg, _, err := image.DecodeConfig(bytes.NewReader(head)); err == nil {
+		width, height = imgCfg.Width, imgCfg.Height
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	mw := multipart.NewWriter(pw)
+	written := make(chan error, 1)
+	go func() {
+		err := writeUpload(mw, filename, contentType, &progressReader{
+			r:        br,
+			total:    total,
+			limit:    limit,
+			progress: cfg.progress,
+		})
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	url := fmt.Sprintf("%s/pf/media", p.BaseURL)
+	respBody, err := p.do(ctx, &call{
+		method:      "POST",
+		url:         url,
+		body:        pr,
+		contentType: mw.FormDataContentType(),
+	})
+	if err != nil {
+		pr.Close()
+		if writeErr := <-written; errors.Is(writeErr, ErrMediaTooLarge) {
+			err = writeErr
+		}
+
+		return Media{}, fmt.Errorf("could not upload media: %w", err)
+	}
+
+	var media Media
+	if err := json.Unmarshal(respBody, &media); err != nil {
+		return Media{}, fmt.Errorf("could not upload media: %w", err)
+	}
+
+	if media.Width == 0 && media.Height == 0 {
+		media.Width, media.Height = width, height
+	}
+
+	if media.Type == "" {
+		media.Type, _, _ = strings.Cut(contentType, "/")
+	}
+
+	return media, nil
+}
+
+func writeUpload(mw *multipart.Writer, filename string, contentType string, body io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filepath.Base(filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, body); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// uploadLimit returns the account's upload limit in bytes. It uses the
+// profile loaded by GetMe, or fetches /me once and remembers the limit
+// without touching Me. Zero means no known limit.
+func (p *Primfeed) uploadLimit(ctx context.Context) (int64, error) {
+	p.mu.RLock()
+	mb := p.Me.Profile.Subscription.MaximumMbUploadSize
+	cached := p.uploadLimitMb
+	p.mu.RUnlock()
+
+	if mb == 0 && cached != nil {
+		mb = *cached
+	}
+
+	if mb == 0 && cached == nil {
+		var profile Profile
+		url := fmt.Sprintf("%s/me", p.BaseURL)
+		if err := p.RequestContext(ctx, "GET", url, nil, nil, &profile); err != nil {
+			return 0, fmt.Errorf("could not get profile: %w", err)
+		}
+
+		mb = profile.Subscription.MaximumMbUploadSize
+
+		p.mu.Lock()
+		p.uploadLimitMb = &mb
+		p.mu.Unlock()
+	}
+
+	return int64(mb) << 20, nil
+}
+
+// readerSize returns how many bytes are left in r, or -1 if r can't tell.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+
+		size := info.Size()
+		if seeker, ok := r.(io.Seeker); ok {
+			if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				size -= offset
+			}
+		}
+
+		return size
+	}
+
+	return -1
+}
+
+func detectContentType(head []byte, filename string) string {
+	contentType := http.DetectContentType(head)
+	if contentType == "application/octet-stream" || strings.HasPrefix(contentType, "text/plain") {
+		if byExt := mime.TypeByExtension(filepath.Ext(filename)); byExt != "" {
+			contentType = byExt
+		}
+	}
+
+	return contentType
+}
+
+// progressReader reports progress and stops the upload once it goes over
+// limit.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	limit    int64
+	progress func(sent int64, total int64)
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.sent += int64(n)
+
+	if pr.limit > 0 && pr.sent > pr.limit {
+		return n, fmt.Errorf("upload is over %d bytes: %w", pr.limit, ErrMediaTooLarge)
+	}
+
+	if pr.progress != nil && n > 0 {
+		pr.progress(pr.sent, pr.total)
+	}
+
+	return n, err
+}
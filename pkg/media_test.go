@@ -0,0 +1,108 @@
+package primfeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMediaServer(maxMb int, uploaded *[]byte) *httptest.Server {
+	return newCountingMediaServer(maxMb, uploaded, new(int))
+}
+
+func newCountingMediaServer(maxMb int, uploaded *[]byte, meCalls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/me":
+			*meCalls++
+			json.NewEncoder(w).Encode(Profile{Subscription: Subscription{MaximumMbUploadSize: maxMb}})
+		case "/pf/media":
+			file, header, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			*uploaded, _ = io.ReadAll(file)
+			fmt.Fprintf(w, `{"id": "m1", "url": "https://cdn.example.com/%s"}`, header.Filename)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestUploadMedia(t *testing.T) {
+	// Arrange
+	var img bytes.Buffer
+	assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 40, 30))))
+	want := img.Bytes()
+
+	var uploaded []byte
+	mockServer := newMediaServer(1, &uploaded)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	var sent, total int64
+	progress := func(s int64, t int64) { sent, total = s, t }
+
+	// Act
+	media, err := pf.UploadMedia(context.Background(), bytes.NewReader(want), "shot.png", OnProgress(progress))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, want, uploaded)
+	assert.Equal(t, "m1", media.ID)
+	assert.Equal(t, "https://cdn.example.com/shot.png", media.URL)
+	assert.Equal(t, "image", media.Type)
+	assert.Equal(t, 40, media.Width)
+	assert.Equal(t, 30, media.Height)
+	assert.Equal(t, int64(len(want)), sent)
+	assert.Equal(t, int64(len(want)), total)
+}
+
+func TestUploadMediaTooLarge(t *testing.T) {
+	// Arrange
+	var uploaded []byte
+	mockServer := newMediaServer(1, &uploaded)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	big := make([]byte, 1<<20+1)
+
+	// Act
+	_, knownErr := pf.UploadMedia(context.Background(), bytes.NewReader(big), "big.bin")
+	_, streamedErr := pf.UploadMedia(context.Background(), io.MultiReader(bytes.NewReader(big)), "big.bin")
+
+	// Assert
+	assert.ErrorIs(t, knownErr, ErrMediaTooLarge)
+	assert.ErrorIs(t, streamedErr, ErrMediaTooLarge)
+	assert.Empty(t, uploaded)
+}
+
+func TestUploadMediaLeavesMeAlone(t *testing.T) {
+	// Arrange
+	var uploaded []byte
+	var meCalls int
+	mockServer := newCountingMediaServer(0, &uploaded, &meCalls)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	ctx := context.Background()
+
+	// Act
+	_, firstErr := pf.UploadMedia(ctx, bytes.NewReader([]byte("one")), "one.txt")
+	_, secondErr := pf.UploadMedia(ctx, bytes.NewReader([]byte("two")), "two.txt")
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 1, meCalls)
+	assert.Equal(t, Profile{}, pf.Snapshot().Profile)
+}
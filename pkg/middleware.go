@@ -0,0 +1,140 @@
+package primfeed
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Doer executes an HTTP request. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behaviour around every HTTP call the
+// client makes.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares around the client's HTTP calls. The first
+// middleware is the outermost one and sees the request first.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(p *Primfeed) {
+		p.middleware = append(p.middleware, mws...)
+	}
+}
+
+// chain composes the middlewares around the HTTP client.
+func (p *Primfeed) chain() Doer {
+	var doer Doer = p.httpClient()
+	for i := len(p.middleware) - 1; i >= 0; i-- {
+		doer = p.middleware[i](doer)
+	}
+
+	return doer
+}
+
+func (p *Primfeed) doer() Doer {
+	if p.chained == nil {
+		return p.chain()
+	}
+
+	return p.chained
+}
+
+// HeaderMiddleware sets the given headers on every request, e.g. tracing
+// or correlation IDs.
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, value := range headers {
+				req.Header.Set(key, value)
+			}
+
+			return next.Do(req)
+		})
+	}
+}
+
+// LoggingMiddleware logs every HTTP call at debug level. Credentials in
+// the headers are redacted.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Any("headers", redactHeader(req.Header)),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+			} else {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			}
+
+			logger.LogAttrs(req.Context(), slog.LevelDebug, "primfeed http call", attrs...)
+
+			return resp, err
+		})
+	}
+}
+
+// RedactTokens runs observer, typically a third party logging or tracing
+// middleware, against a copy of each request with its credentials
+// redacted. The request that reaches the network keeps them, along with
+// any headers observer added.
+func RedactTokens(observer Middleware) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			original := req.Header
+
+			restore := DoerFunc(func(r *http.Request) (*http.Response, error) {
+				r = r.Clone(r.Context())
+				for _, key := range sensitiveHeaders {
+					if values, ok := original[key]; ok {
+						r.Header[key] = values
+					}
+				}
+
+				return next.Do(r)
+			})
+
+			redacted := req.Clone(req.Context())
+			redacted.Header = redactHeader(original)
+
+			return observer(restore).Do(redacted)
+		})
+	}
+}
+
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+const redactedValue = "[REDACTED]"
+
+func redactHeader(h http.Header) http.Header {
+	clone := h.Clone()
+	for _, key := range sensitiveHeaders {
+		if _, ok := clone[key]; ok {
+			clone[key] = []string{redactedValue}
+		}
+	}
+
+	return clone
+}
@@ -0,0 +1,85 @@
+package primfeed
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	// Arrange
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	var traceID string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceID = r.Header.Get("X-Trace-Id")
+		w.Write([]byte(`0`))
+	}))
+	defer mockServer.Close()
+
+	pf := NewPrimfeed(mockServer.URL, WithMiddleware(
+		record("outer"),
+		HeaderMiddleware(map[string]string{"X-Trace-Id": "abc"}),
+		record("inner"),
+	))
+
+	// Act
+	_, err := pf.GetNotificationCount()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, "abc", traceID)
+}
+
+func TestRedactTokens(t *testing.T) {
+	// Arrange
+	var auth, traceID string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		traceID = r.Header.Get("X-Trace-Id")
+		w.Write([]byte(`0`))
+	}))
+	defer mockServer.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	var observed string
+	observer := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			observed = req.Header.Get("Authorization")
+			req.Header.Set("X-Trace-Id", "abc")
+			return next.Do(req)
+		})
+	}
+
+	pf := NewPrimfeed(mockServer.URL, WithMiddleware(
+		LoggingMiddleware(logger),
+		RedactTokens(observer),
+	))
+	pf.SetToken("0123456789abcdef")
+
+	// Act
+	_, err := pf.GetNotificationCount()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 0123456789abcdef", auth)
+	assert.Equal(t, "abc", traceID)
+	assert.Equal(t, redactedValue, observed)
+	assert.NotContains(t, logs.String(), "0123456789abcdef")
+	assert.Contains(t, logs.String(), "status=200")
+}
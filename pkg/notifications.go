@@ -0,0 +1,157 @@
+package primfeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationType says what a Notification is about. Types the library
+// doesn't know yet are kept as is rather than dropped; check Known.
+type NotificationType string
+
+const (
+	NotificationFollow      NotificationType = "follow"
+	NotificationLike        NotificationType = "like"
+	NotificationComment     NotificationType = "comment"
+	NotificationReply       NotificationType = "reply"
+	NotificationCommentLike NotificationType = "comment_like"
+	NotificationMention     NotificationType = "mention"
+	NotificationQuote       NotificationType = "quote"
+)
+
+// Known reports whether t is one of the NotificationType constants.
+func (t NotificationType) Known() bool {
+	switch t {
+	case NotificationFollow, NotificationLike, NotificationComment, NotificationReply,
+		NotificationCommentLike, NotificationMention, NotificationQuote:
+		return true
+	}
+
+	return false
+}
+
+// GroupID identifies what a group of notifications is about, usually a
+// post. The API sends it either as a string or as a number.
+type GroupID string
+
+func (id *GroupID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*id = ""
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = GroupID(s)
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("groupId: %w", err)
+		}
+		*id = GroupID(n.String())
+	}
+
+	return nil
+}
+
+func (n Notification) IsFollow() bool {
+	return n.Type == NotificationFollow
+}
+
+func (n Notification) IsLike() bool {
+	return n.Type == NotificationLike || n.Type == NotificationCommentLike
+}
+
+func (n Notification) IsComment() bool {
+	return n.Type == NotificationComment || n.Type == NotificationReply
+}
+
+func (n Notification) IsMention() bool {
+	return n.Type == NotificationMention
+}
+
+func (n Notification) IsQuote() bool {
+	return n.Type == NotificationQuote
+}
+
+// TargetPostID returns the ID of the post the notification is about, or ""
+// for notifications that aren't about a post, such as follows.
+func (n Notification) TargetPostID() string {
+	switch n.Type {
+	case NotificationLike, NotificationComment, NotificationReply,
+		NotificationCommentLike, NotificationMention, NotificationQuote:
+		return string(n.GroupID)
+	}
+
+	return ""
+}
+
+type markReadInput struct {
+	IDs []string `json:"ids"`
+}
+
+func (p *Primfeed) MarkNotificationRead(ctx context.Context, id string) error {
+	return p.MarkNotificationsRead(ctx, id)
+}
+
+// MarkNotificationsRead marks the given notifications as read and lowers
+// the cached unread count to match.
+func (p *Primfeed) MarkNotificationsRead(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/notifications/read", p.BaseURL)
+
+	err := p.RequestContext(ctx, "POST", url, markReadInput{IDs: ids}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not mark notifications read: %w", err)
+	}
+
+	read := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		read[id] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.Me.Notifications.Notifications {
+		subs := p.Me.Notifications.Notifications[i].Notifications
+		for j := range subs {
+			if read[subs[j].ID] && !subs[j].Read {
+				subs[j].Read = true
+				p.Me.Notifications.UnreadCount = max(p.Me.Notifications.UnreadCount-1, 0)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Primfeed) MarkAllNotificationsRead(ctx context.Context) error {
+	url := fmt.Sprintf("%s/notifications/read-all", p.BaseURL)
+
+	err := p.RequestContext(ctx, "POST", url, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not mark notifications read: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range p.Me.Notifications.Notifications {
+		subs := p.Me.Notifications.Notifications[i].Notifications
+		for j := range subs {
+			subs[j].Read = true
+		}
+	}
+	p.Me.Notifications.UnreadCount = 0
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package primfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationDecoding(t *testing.T) {
+	// Arrange
+	body := `{"unreadCount": 3, "notifications": [
+		{"type": "follow", "groupId": null, "notifications": [{"id": "n1"}]},
+		{"type": "like", "groupId": "p1", "notifications": [{"id": "n2"}]},
+		{"type": "comment", "groupId": 12345, "notifications": [{"id": "n3"}]},
+		{"type": "sparkle", "groupId": "x", "notifications": [{"id": "n4"}]}
+	]}`
+
+	// Act
+	var resp NotificationsResponse
+	err := json.Unmarshal([]byte(body), &resp)
+
+	// Assert
+	assert.NoError(t, err)
+	follow, like, comment, unknown := resp.Notifications[0], resp.Notifications[1], resp.Notifications[2], resp.Notifications[3]
+
+	assert.True(t, follow.IsFollow())
+	assert.Equal(t, "", follow.TargetPostID())
+
+	assert.True(t, like.IsLike())
+	assert.Equal(t, "p1", like.TargetPostID())
+
+	assert.True(t, comment.IsComment())
+	assert.Equal(t, GroupID("12345"), comment.GroupID)
+	assert.Equal(t, "12345", comment.TargetPostID())
+
+	assert.False(t, unknown.Type.Known())
+	assert.Equal(t, NotificationType("sparkle"), unknown.Type)
+	assert.Equal(t, GroupID("x"), unknown.GroupID)
+	assert.Equal(t, "n4", unknown.Notifications[0].ID)
+}
+
+func newMarkReadServer(got *markReadInput, readAll *bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/notifications":
+			fmt.Fprint(w, `{"unreadCount": 3, "notifications": [
+				{"type": "like", "notifications": [{"id": "n1"}, {"id": "n2"}, {"id": "n0", "read": true}]},
+				{"type": "follow", "notifications": [{"id": "n3"}]}
+			]}`)
+		case "/notifications/read":
+			json.NewDecoder(r.Body).Decode(got)
+		case "/notifications/read-all":
+			*readAll = true
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	// Arrange
+	var got markReadInput
+	var readAll bool
+	mockServer := newMarkReadServer(&got, &readAll)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	ctx := context.Background()
+	_, err := pf.GetNotifications()
+	assert.NoError(t, err)
+
+	// Act
+	err = pf.MarkNotificationsRead(ctx, "n1", "n0", "unknown")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1", "n0", "unknown"}, got.IDs)
+	me := pf.Snapshot()
+	assert.Equal(t, 2, me.Notifications.UnreadCount)
+	assert.True(t, me.Notifications.Notifications[0].Notifications[0].Read)
+	assert.False(t, me.Notifications.Notifications[0].Notifications[1].Read)
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	// Arrange
+	var got markReadInput
+	var readAll bool
+	mockServer := newMarkReadServer(&got, &readAll)
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	_, err := pf.GetNotifications()
+	assert.NoError(t, err)
+
+	// Act
+	err = pf.MarkAllNotificationsRead(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, readAll)
+	me := pf.Snapshot()
+	assert.Equal(t, 0, me.Notifications.UnreadCount)
+	assert.True(t, me.Notifications.Notifications[1].Notifications[0].Read)
+}
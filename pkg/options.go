@@ -0,0 +1,73 @@
+package primfeed
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Primfeed client in NewPrimfeed.
+type Option func(*Primfeed)
+
+// WithHTTPClient makes the client send requests through a copy of c.
+// WithTimeout and WithTransport adjust the copy, never c itself, whatever
+// order the options are passed in.
+func WithHTTPClient(c *http.Client) Option {
+	return func(p *Primfeed) {
+		if c == nil {
+			return
+		}
+
+		client := *c
+		p.client = &client
+	}
+}
+
+// WithTimeout caps the time spent on a single HTTP request, including
+// reading the response body.
+func WithTimeout(d time.Duration) Option {
+	return func(p *Primfeed) {
+		p.timeout = &d
+	}
+}
+
+// WithTransport sets the RoundTripper used for every request, e.g. a proxy
+// aware transport or a stub in tests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(p *Primfeed) {
+		p.transport = rt
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(p *Primfeed) {
+		p.userAgent = ua
+	}
+}
+
+// WithBaseURL overrides the base URL passed to NewPrimfeed.
+func WithBaseURL(baseUrl string) Option {
+	return func(p *Primfeed) {
+		p.BaseURL = baseUrl
+	}
+}
+
+// applyClientOptions sets the timeout and transport once every option has
+// run, so a later WithHTTPClient doesn't discard them.
+func (p *Primfeed) applyClientOptions() {
+	if p.timeout != nil {
+		p.client.Timeout = *p.timeout
+	}
+
+	if p.transport != nil {
+		p.client.Transport = p.transport
+	}
+}
+
+func (p *Primfeed) httpClient() *http.Client {
+	if p.client == nil {
+		return http.DefaultClient
+	}
+
+	return p.client
+}
@@ -0,0 +1,76 @@
+package primfeed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewPrimfeedOptions(t *testing.T) {
+	// Arrange
+	shared := &http.Client{}
+
+	// Act
+	pf := NewPrimfeed(APIURL,
+		WithHTTPClient(shared),
+		WithTimeout(5*time.Second),
+		WithUserAgent("primfeed-test"),
+		WithBaseURL("example.com"),
+	)
+
+	// Assert
+	assert.Equal(t, "https://example.com", pf.BaseURL)
+	assert.Equal(t, 5*time.Second, pf.client.Timeout)
+	assert.Equal(t, time.Duration(0), shared.Timeout)
+	assert.Equal(t, "primfeed-test", pf.userAgent)
+}
+
+func TestWithTransport(t *testing.T) {
+	// Arrange
+	var seen *http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		seen = req
+		rec := httptest.NewRecorder()
+		rec.WriteString(`5`)
+		return rec.Result(), nil
+	})
+	pf := NewPrimfeed(APIURL, WithTransport(transport), WithUserAgent("primfeed-test"))
+	pf.SetToken("0123456789abcdef")
+
+	// Act
+	count, err := pf.GetNotificationCount()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.Equal(t, "/notifications/count", seen.URL.Path)
+	assert.Equal(t, "primfeed-test", seen.Header.Get("User-Agent"))
+	assert.Equal(t, "Bearer 0123456789abcdef", seen.Header.Get("Authorization"))
+}
+
+func TestClientOptionsIgnoreOrder(t *testing.T) {
+	// Arrange
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return httptest.NewRecorder().Result(), nil
+	})
+
+	// Act
+	pf := NewPrimfeed(APIURL,
+		WithTimeout(5*time.Second),
+		WithTransport(transport),
+		WithHTTPClient(&http.Client{}),
+	)
+
+	// Assert
+	assert.Equal(t, 5*time.Second, pf.client.Timeout)
+	assert.NotNil(t, pf.client.Transport)
+}
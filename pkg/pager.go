@@ -0,0 +1,206 @@
+package primfeed
+
+import (
+	"context"
+	"iter"
+)
+
+// Pager walks a paginated endpoint one item at a time, in the style of
+// bufio.Scanner:
+//
+//	pager := pf.FeedPager(ctx, id)
+//	for pager.Next() {
+//		post := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+//
+// Items that show up again on a later page, e.g. because new posts pushed
+// them down, are only returned once. The pager stops at the first empty
+// page, or at a page that holds nothing new.
+type Pager[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int) ([]T, error)
+	key   func(T) string
+	cfg   pagerConfig
+
+	page    int
+	fetched int
+	buf     []T
+	item    T
+	seen    map[string]struct{}
+	count   int
+	done    bool
+	err     error
+}
+
+type pagerConfig struct {
+	startPage int
+	maxItems  int
+	stopAt    string
+	onPage    func(page int, items int)
+}
+
+// PagerOption configures a Pager.
+type PagerOption func(*pagerConfig)
+
+// StartPage sets the first page to fetch. Pages start at 1.
+func StartPage(page int) PagerOption {
+	return func(c *pagerConfig) {
+		c.startPage = page
+	}
+}
+
+// MaxItems stops the pager after n items.
+func MaxItems(n int) PagerOption {
+	return func(c *pagerConfig) {
+		c.maxItems = n
+	}
+}
+
+// OnPage calls fn after each page is fetched with the page number and
+// how many distinct items have been fetched so far.
+func OnPage(fn func(page int, items int)) PagerOption {
+	return func(c *pagerConfig) {
+		c.onPage = fn
+	}
+}
+
+// StopAt stops the pager when it reaches the item with the given ID,
+// without returning it. Pass the newest ID seen on a previous run to only
+// get what is new since.
+func StopAt(id string) PagerOption {
+	return func(c *pagerConfig) {
+		c.stopAt = id
+	}
+}
+
+func newPager[T any](ctx context.Context, fetch func(ctx context.Context, page int) ([]T, error), key func(T) string, opts []PagerOption) *Pager[T] {
+	cfg := pagerConfig{startPage: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &Pager[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		key:   key,
+		cfg:   cfg,
+		page:  cfg.startPage,
+		seen:  make(map[string]struct{}),
+	}
+}
+
+// Next advances to the next item, fetching pages as needed. It returns
+// false when there are no more items or an error occurred.
+func (pg *Pager[T]) Next() bool {
+	for !pg.done && pg.err == nil {
+		if pg.cfg.maxItems > 0 && pg.count >= pg.cfg.maxItems {
+			pg.done = true
+			break
+		}
+
+		if len(pg.buf) == 0 {
+			pg.fill()
+			continue
+		}
+
+		item := pg.buf[0]
+		pg.buf = pg.buf[1:]
+
+		key := pg.key(item)
+		if pg.cfg.stopAt != "" && key == pg.cfg.stopAt {
+			pg.done = true
+			break
+		}
+
+		if _, ok := pg.seen[key]; ok {
+			continue
+		}
+
+		pg.seen[key] = struct{}{}
+		pg.item = item
+		pg.count++
+
+		return true
+	}
+
+	return false
+}
+
+func (pg *Pager[T]) fill() {
+	if err := pg.ctx.Err(); err != nil {
+		pg.err = err
+		return
+	}
+
+	items, err := pg.fetch(pg.ctx, pg.page)
+	if err != nil {
+		pg.err = err
+		return
+	}
+
+	fresh := 0
+	for _, item := range items {
+		if _, ok := pg.seen[pg.key(item)]; !ok {
+			fresh++
+		}
+	}
+
+	pg.fetched += fresh
+	if pg.cfg.onPage != nil {
+		pg.cfg.onPage(pg.page, pg.fetched)
+	}
+
+	pg.page++
+
+	if fresh == 0 {
+		pg.done = true
+		return
+	}
+
+	pg.buf = items
+}
+
+// Item returns the item Next advanced to.
+func (pg *Pager[T]) Item() T {
+	return pg.item
+}
+
+// Err returns the error that stopped the pager, if any.
+func (pg *Pager[T]) Err() error {
+	return pg.err
+}
+
+// All returns an iterator over the remaining items. An error ends the
+// sequence, paired with the zero T.
+func (pg *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for pg.Next() {
+			if !yield(pg.Item(), nil) {
+				return
+			}
+		}
+
+		if err := pg.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// FeedPager pages through the feed of the entity id.
+func (p *Primfeed) FeedPager(ctx context.Context, id string, opts ...PagerOption) *Pager[Feed] {
+	fetch := func(ctx context.Context, page int) ([]Feed, error) {
+		resp, err := p.GetFeedContext(ctx, id, page)
+		return resp.Feed, err
+	}
+
+	return newPager(ctx, fetch, func(f Feed) string { return f.Data.ID }, opts)
+}
+
+// FeedIter iterates over the feed of the entity id, page after page.
+func (p *Primfeed) FeedIter(ctx context.Context, id string, opts ...PagerOption) iter.Seq2[Feed, error] {
+	return p.FeedPager(ctx, id, opts...).All()
+}
@@ -0,0 +1,94 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFeedServer(pages map[string][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pf/testuser/feed" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var posts []string
+		for _, id := range pages[r.URL.Query().Get("page")] {
+			posts = append(posts, fmt.Sprintf(`{"data": {"id": %q}}`, id))
+		}
+
+		fmt.Fprintf(w, `{"feed": [%s]}`, strings.Join(posts, ","))
+	}))
+}
+
+func collectIDs(t *testing.T, seq func(func(Feed, error) bool)) []string {
+	var ids []string
+	for post, err := range seq {
+		assert.NoError(t, err)
+		ids = append(ids, post.Data.ID)
+	}
+
+	return ids
+}
+
+func TestFeedIter(t *testing.T) {
+	// Arrange
+	mockServer := newFeedServer(map[string][]string{
+		"1": {"p5", "p4"},
+		"2": {"p4", "p3"},
+		"3": {"p2"},
+	})
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	ids := collectIDs(t, pf.FeedIter(context.Background(), "testuser"))
+
+	// Assert
+	assert.Equal(t, []string{"p5", "p4", "p3", "p2"}, ids)
+}
+
+func TestFeedIterOptions(t *testing.T) {
+	// Arrange
+	mockServer := newFeedServer(map[string][]string{
+		"1": {"p5", "p4"},
+		"2": {"p3", "p2"},
+		"3": {"p1"},
+	})
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	ctx := context.Background()
+
+	// Act
+	fromPage := collectIDs(t, pf.FeedIter(ctx, "testuser", StartPage(2)))
+	capped := collectIDs(t, pf.FeedIter(ctx, "testuser", MaxItems(3)))
+	stopped := collectIDs(t, pf.FeedIter(ctx, "testuser", StopAt("p2")))
+
+	// Assert
+	assert.Equal(t, []string{"p3", "p2", "p1"}, fromPage)
+	assert.Equal(t, []string{"p5", "p4", "p3"}, capped)
+	assert.Equal(t, []string{"p5", "p4", "p3"}, stopped)
+}
+
+func TestPagerError(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	pager := pf.FeedPager(context.Background(), "testuser")
+
+	// Act
+	next := pager.Next()
+
+	// Assert
+	assert.False(t, next)
+	assert.Error(t, pager.Err())
+}
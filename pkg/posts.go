@@ -0,0 +1,147 @@
+package primfeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PostInput is the body sent to create or edit a post. It mirrors
+// Feed.Data.
+type PostInput struct {
+	Content       string `json:"content"`
+	Rating        string `json:"rating,omitempty"`
+	IsAi          bool   `json:"isAi"`
+	IsRender      bool   `json:"isRender"`
+	PublicGallery bool   `json:"publicGallery"`
+
+	// QuotedPost is the ID of the post being quoted, if any.
+	QuotedPost string `json:"quotedPost,omitempty"`
+
+	// Media holds the IDs of previously uploaded media.
+	Media []string `json:"media,omitempty"`
+}
+
+func (p *Primfeed) GetPost(ctx context.Context, id string) (Feed, error) {
+	url := fmt.Sprintf("%s/pf/post/%s", p.BaseURL, id)
+
+	var post Feed
+	err := p.RequestContext(ctx, "GET", url, nil, nil, &post)
+	if err != nil {
+		return Feed{}, fmt.Errorf("could not load post: %w", err)
+	}
+
+	return post, nil
+}
+
+// GetPosts loads several posts concurrently, at most WithMaxConcurrency at
+// a time. The result lines up with ids; posts that failed to load are left
+// empty and their errors joined into the returned error.
+func (p *Primfeed) GetPosts(ctx context.Context, ids ...string) ([]Feed, error) {
+	posts := make([]Feed, len(ids))
+	errs := make([]error, len(ids))
+
+	p.forEach(ctx, len(ids), func(ctx context.Context, i int) {
+		posts[i], errs[i] = p.GetPost(ctx, ids[i])
+	})
+
+	return posts, errors.Join(errs...)
+}
+
+func (p *Primfeed) CreatePost(ctx context.Context, input PostInput) (Feed, error) {
+	url := fmt.Sprintf("%s/pf/post", p.BaseURL)
+
+	var post Feed
+	err := p.RequestContext(ctx, "POST", url, input, nil, &post)
+	if err != nil {
+		return Feed{}, fmt.Errorf("could not create post: %w", err)
+	}
+
+	return post, nil
+}
+
+// EditPost replaces the content of post. It fails with ErrForbidden,
+// without calling the API, when post.Perms says we can't edit it.
+func (p *Primfeed) EditPost(ctx context.Context, post Feed, input PostInput) (Feed, error) {
+	if !post.Perms.CanEdit {
+		return Feed{}, fmt.Errorf("cannot edit post %s: %w", post.Data.ID, ErrForbidden)
+	}
+
+	url := fmt.Sprintf("%s/pf/post/%s", p.BaseURL, post.Data.ID)
+
+	var edited Feed
+	err := p.RequestContext(ctx, "PATCH", url, input, nil, &edited)
+	if err != nil {
+		return Feed{}, fmt.Errorf("could not edit post: %w", err)
+	}
+
+	return edited, nil
+}
+
+// DeletePost deletes post. It fails with ErrForbidden, without calling the
+// API, when post.Perms says we can't delete it.
+func (p *Primfeed) DeletePost(ctx context.Context, post Feed) error {
+	if !post.Perms.CanDelete {
+		return fmt.Errorf("cannot delete post %s: %w", post.Data.ID, ErrForbidden)
+	}
+
+	url := fmt.Sprintf("%s/pf/post/%s", p.BaseURL, post.Data.ID)
+
+	err := p.RequestContext(ctx, "DELETE", url, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not delete post: %w", err)
+	}
+
+	return nil
+}
+
+// QuotePost creates a post quoting the post with the given ID.
+func (p *Primfeed) QuotePost(ctx context.Context, postID string, content string) (Feed, error) {
+	return p.CreatePost(ctx, PostInput{Content: content, QuotedPost: postID})
+}
+
+// MaxQuoteDepth is how many levels of quoted posts are decoded. Deeper
+// quotes are dropped, so a malicious or cyclic chain can't blow the stack.
+const MaxQuoteDepth = 8
+
+func (post *Post) UnmarshalJSON(data []byte) error {
+	return post.decode(data, 0)
+}
+
+func (post *Post) decode(data []byte, depth int) error {
+	// plain has Post's fields but not its methods, so decoding into it
+	// doesn't recurse back into UnmarshalJSON.
+	type plain Post
+
+	var raw struct {
+		plain
+		QuotedPost json.RawMessage `json:"quotedPost,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*post = Post(raw.plain)
+
+	quoted := bytes.TrimSpace(raw.QuotedPost)
+	if len(quoted) == 0 || bytes.Equal(quoted, []byte("null")) || depth >= MaxQuoteDepth {
+		return nil
+	}
+
+	// Some responses only reference the quoted post by ID.
+	if quoted[0] == '"' {
+		var id string
+		if err := json.Unmarshal(quoted, &id); err != nil {
+			return err
+		}
+
+		post.QuotedPost = &Post{ID: id}
+		return nil
+	}
+
+	post.QuotedPost = new(Post)
+	return post.QuotedPost.decode(quoted, depth+1)
+}
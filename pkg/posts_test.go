@@ -0,0 +1,170 @@
+package primfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePost(t *testing.T) {
+	// Arrange
+	var input PostInput
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pf/post" && r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&input)
+			fmt.Fprintf(w, `{"perms": {"canEdit": true}, "data": {"id": "p1", "content": %q}}`, input.Content)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	post, err := pf.CreatePost(context.Background(), PostInput{Content: "hello", IsRender: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, input.IsRender)
+	assert.Equal(t, "p1", post.Data.ID)
+	assert.Equal(t, "hello", post.Data.Content)
+	assert.True(t, post.Perms.CanEdit)
+}
+
+func TestEditDeletePostPerms(t *testing.T) {
+	// Arrange
+	var calls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/pf/post/p1" && r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+	ctx := context.Background()
+
+	var post Feed
+	post.Data.ID = "p1"
+
+	// Act
+	_, editErr := pf.EditPost(ctx, post, PostInput{Content: "changed"})
+	deleteErr := pf.DeletePost(ctx, post)
+	post.Perms.CanDelete = true
+	allowedErr := pf.DeletePost(ctx, post)
+
+	// Assert
+	assert.ErrorIs(t, editErr, ErrForbidden)
+	assert.ErrorIs(t, deleteErr, ErrForbidden)
+	assert.NoError(t, allowedErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestQuotedPostDecoding(t *testing.T) {
+	// Arrange
+	body := `{"data": {"id": "p3", "quotedPost": {"id": "p2", "owner": {"handle": "creator"},
+		"media": [{"id": "m1"}], "quotedPost": {"id": "p1", "quotedPost": "p0"}}}}`
+
+	// Act
+	var post Feed
+	err := json.Unmarshal([]byte(body), &post)
+
+	// Assert
+	assert.NoError(t, err)
+	quoted := post.Data.QuotedPost
+	assert.Equal(t, "p2", quoted.ID)
+	assert.Equal(t, "creator", quoted.Owner.Handle)
+	assert.Equal(t, "m1", quoted.Media[0].ID)
+	assert.Equal(t, "p1", quoted.QuotedPost.ID)
+	assert.Equal(t, "p0", quoted.QuotedPost.QuotedPost.ID)
+}
+
+func TestQuotedPostDepthGuard(t *testing.T) {
+	// Arrange
+	body := `{"id": "leaf"}`
+	for i := 0; i < MaxQuoteDepth+5; i++ {
+		body = fmt.Sprintf(`{"id": "p%d", "quotedPost": %s}`, i, body)
+	}
+
+	// Act
+	var post Post
+	err := json.Unmarshal([]byte(body), &post)
+
+	// Assert
+	assert.NoError(t, err)
+	depth := 0
+	for q := post.QuotedPost; q != nil; q = q.QuotedPost {
+		depth++
+	}
+	assert.Equal(t, MaxQuoteDepth, depth)
+}
+
+func TestQuotePost(t *testing.T) {
+	// Arrange
+	var input PostInput
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&input)
+		fmt.Fprintf(w, `{"data": {"id": "p2", "quotedPost": {"id": %q}}}`, input.QuotedPost)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	post, err := pf.QuotePost(context.Background(), "p1", "look at this")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "look at this", input.Content)
+	assert.Equal(t, "p1", post.Data.QuotedPost.ID)
+}
+
+func TestGetPosts(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/pf/post/")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprintf(w, `{"likes": 3, "commentsCount": 1, "perms": {"canReport": true}, "data": {"id": %q}}`, id)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithMaxConcurrency(2))
+
+	// Act
+	posts, err := pf.GetPosts(context.Background(), "p1", "p2", "missing", "p4", "p5")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Len(t, posts, 5)
+	assert.Equal(t, "p1", posts[0].Data.ID)
+	assert.Equal(t, "", posts[2].Data.ID)
+	assert.Equal(t, "p5", posts[4].Data.ID)
+	assert.Equal(t, 3, posts[4].Likes)
+	assert.True(t, posts[4].Perms.CanReport)
+	assert.LessOrEqual(t, peak, 2)
+}
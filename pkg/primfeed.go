@@ -2,16 +2,22 @@ package primfeed
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Notification struct {
-	Type          string            `json:"type"`
-	GroupID       interface{}       `json:"groupId,omitempty"`
+	Type          NotificationType  `json:"type"`
+	GroupID       GroupID           `json:"groupId,omitempty"`
 	CreatedAt     string            `json:"createdAt"`
 	Notifications []SubNotification `json:"notifications"`
 }
@@ -129,34 +135,72 @@ type Feed struct {
 	Likes         int   `json:"likes,omitempty"`
 	Liked         bool  `json:"liked,omitempty"`
 	Perms         Perms `json:"perms,omitempty"`
-	Data          struct {
-		ID            string  `json:"id,omitempty"`
-		Owner         User    `json:"owner,omitempty"`
-		QuotedPost    any     `json:"quotedPost,omitempty"`
-		CreatedAt     int     `json:"createdAt,omitempty"`
-		UpdatedAt     any     `json:"updatedAt,omitempty"`
-		Content       string  `json:"content,omitempty"`
-		Rating        string  `json:"rating,omitempty"`
-		IsAi          bool    `json:"isAi,omitempty"`
-		IsRender      bool    `json:"isRender,omitempty"`
-		PublicGallery bool    `json:"publicGallery,omitempty"`
-		Media         []Media `json:"media,omitempty"`
-	} `json:"data,omitempty"`
+	Data          Post  `json:"data,omitempty"`
+}
+
+type Post struct {
+	ID            string  `json:"id,omitempty"`
+	Owner         User    `json:"owner,omitempty"`
+	QuotedPost    *Post   `json:"quotedPost,omitempty"`
+	CreatedAt     int     `json:"createdAt,omitempty"`
+	UpdatedAt     any     `json:"updatedAt,omitempty"`
+	Content       string  `json:"content,omitempty"`
+	Rating        string  `json:"rating,omitempty"`
+	IsAi          bool    `json:"isAi,omitempty"`
+	IsRender      bool    `json:"isRender,omitempty"`
+	PublicGallery bool    `json:"publicGallery,omitempty"`
+	Media         []Media `json:"media,omitempty"`
 }
 
 type FeedResponse struct {
 	Feed []Feed `json:"feed"`
 }
 
+// Me is the state the client caches about the logged in account.
+type Me struct {
+	Profile       Profile
+	Notifications NotificationsResponse
+	Followers     Followers
+	Following     Followers
+}
+
+// Primfeed is safe for concurrent use by multiple goroutines. Its methods
+// guard Token and Me internally, but reading those fields directly while
+// other goroutines use the client is a data race; use CurrentToken and
+// Snapshot instead.
 type Primfeed struct {
 	Token   string
 	BaseURL string
-	Me      struct {
-		Profile       Profile
-		Notifications NotificationsResponse
-		Followers     Followers
-		Following     Followers
-	}
+	Me      Me
+
+	mu sync.RWMutex
+
+	client    *http.Client
+	timeout   *time.Duration
+	transport http.RoundTripper
+	userAgent string
+	retry     *RetryPolicy
+
+	limiter       *RateLimiter
+	groupLimiters map[EndpointGroup]*RateLimiter
+
+	middleware []Middleware
+	chained    Doer
+
+	logger *slog.Logger
+
+	maxConcurrency int
+
+	followCacheTTL time.Duration
+	followCache    map[string]cachedFollows
+
+	handles *HandleCache
+
+	cache CacheStore
+
+	// uploadLimitMb is the upload limit fetched by UploadMedia when GetMe
+	// hasn't run.
+	uploadLimitMb *int
 }
 
 type LoginRequest struct {
@@ -196,21 +240,77 @@ const (
 	URL    string = "www.primfeed.com"
 )
 
-func NewPrimfeed(baseUrl string) *Primfeed {
-	if !strings.HasPrefix(baseUrl, "http") {
-		baseUrl = fmt.Sprintf("https://%s", baseUrl)
+func NewPrimfeed(baseUrl string, opts ...Option) *Primfeed {
+	p := &Primfeed{
+		BaseURL: baseUrl,
+		client:  &http.Client{},
+		handles: NewHandleCache(defaultHandleCacheSize, defaultHandleCacheTTL),
+	}
+
+	for _, opt := range opts {
+		opt(p)
 	}
 
-	return &Primfeed{
-		BaseURL: fmt.Sprintf("%s", baseUrl),
+	if !strings.HasPrefix(p.BaseURL, "http") {
+		p.BaseURL = fmt.Sprintf("https://%s", p.BaseURL)
 	}
+
+	p.applyClientOptions()
+	p.chained = p.chain()
+
+	return p
 }
 
 func (p *Primfeed) SetToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.Token = token
 }
 
+func (p *Primfeed) CurrentToken() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.Token
+}
+
+// Snapshot returns a copy of Me that later calls on the client won't
+// modify.
+func (p *Primfeed) Snapshot() Me {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	me := p.Me
+	me.Followers = slices.Clone(me.Followers)
+	me.Following = slices.Clone(me.Following)
+	me.Notifications = cloneNotifications(me.Notifications)
+
+	return me
+}
+
+func cloneNotifications(n NotificationsResponse) NotificationsResponse {
+	n.Notifications = slices.Clone(n.Notifications)
+	for i := range n.Notifications {
+		n.Notifications[i].Notifications = slices.Clone(n.Notifications[i].Notifications)
+	}
+
+	return n
+}
+
+// handle returns the logged in account's handle.
+func (p *Primfeed) handle() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.Me.Profile.User.Handle
+}
+
 func (p *Primfeed) Login(username string, password string, company any) (LoginResponse, error) {
+	return p.LoginContext(context.Background(), username, password, company)
+}
+
+func (p *Primfeed) LoginContext(ctx context.Context, username string, password string, company any) (LoginResponse, error) {
 	loginRequest := LoginRequest{
 		Username:  username,
 		Password:  password,
@@ -221,9 +321,13 @@ func (p *Primfeed) Login(username string, password string, company any) (LoginRe
 	var loginResponse LoginResponse
 	url := fmt.Sprintf("%s/login", p.BaseURL)
 
-	err := p.Request("POST", url, loginRequest, nil, &loginResponse)
+	err := p.RequestContext(ctx, "POST", url, loginRequest, nil, &loginResponse)
 	if err != nil {
-		return LoginResponse{}, fmt.Errorf("login failed: %v\n\n", err)
+		return LoginResponse{}, fmt.Errorf("login failed: %w", err)
+	}
+
+	if loginResponse.Error != "" {
+		return loginResponse, fmt.Errorf("login failed: %w", newLoginError(url, loginResponse.Error))
 	}
 
 	p.SetToken(loginResponse.Token)
@@ -233,6 +337,10 @@ func (p *Primfeed) Login(username string, password string, company any) (LoginRe
 }
 
 func (p *Primfeed) GetLoginCode(username string) (LoginInworldResponse, error) {
+	return p.GetLoginCodeContext(context.Background(), username)
+}
+
+func (p *Primfeed) GetLoginCodeContext(ctx context.Context, username string) (LoginInworldResponse, error) {
 
 	var loginInworldResponse LoginInworldResponse
 	url := fmt.Sprintf("%s/login/create-inworld-request", p.BaseURL)
@@ -241,24 +349,30 @@ func (p *Primfeed) GetLoginCode(username string) (LoginInworldResponse, error) {
 		Username: username,
 	}
 
-	err := p.Request("POST", url, loginInWorldRequest, nil, &loginInworldResponse)
+	err := p.RequestContext(ctx, "POST", url, loginInWorldRequest, nil, &loginInworldResponse)
 	if err != nil {
-		return LoginInworldResponse{}, fmt.Errorf("could not send inworld request: %v", err)
+		return LoginInworldResponse{}, fmt.Errorf("could not send inworld request: %w", err)
 	}
 
 	// This is hacky.
+	p.mu.Lock()
 	p.Me.Profile.User.Handle = username
+	p.mu.Unlock()
 
 	return loginInworldResponse, nil
 }
 
 func (p *Primfeed) LoginWithCode(requestId string, code string, company string) (LoginResponse, error) {
+	return p.LoginWithCodeContext(context.Background(), requestId, code, company)
+}
+
+func (p *Primfeed) LoginWithCodeContext(ctx context.Context, requestId string, code string, company string) (LoginResponse, error) {
 
 	var loginResponse LoginResponse
 
 	loginInworldCode := LoginInworldCodeRequest{
 		RequestID: requestId,
-		Username:  p.Me.Profile.User.Handle,
+		Username:  p.handle(),
 		OTP:       code,
 		CompanyID: company,
 		Redirect:  "/",
@@ -266,9 +380,13 @@ func (p *Primfeed) LoginWithCode(requestId string, code string, company string)
 
 	url := fmt.Sprintf("%s/login/inworld-code", p.BaseURL)
 
-	err := p.Request("POST", url, loginInworldCode, nil, &loginResponse)
+	err := p.RequestContext(ctx, "POST", url, loginInworldCode, nil, &loginResponse)
 	if err != nil {
-		return LoginResponse{}, fmt.Errorf("could not login with code: %v", err)
+		return LoginResponse{}, fmt.Errorf("could not login with code: %w", err)
+	}
+
+	if loginResponse.Error != "" {
+		return loginResponse, fmt.Errorf("could not login with code: %w", newLoginError(url, loginResponse.Error))
 	}
 
 	p.SetToken(loginResponse.Token)
@@ -277,7 +395,13 @@ func (p *Primfeed) LoginWithCode(requestId string, code string, company string)
 }
 
 func (p *Primfeed) Request(method string, path string, data interface{}, headers map[string]string, target interface{}) error {
-	var body *bytes.Buffer
+	return p.RequestContext(context.Background(), method, path, data, headers, target)
+}
+
+// RequestContext is like Request but the HTTP call is bound to ctx, so
+// cancelling ctx or hitting its deadline aborts the in-flight request.
+func (p *Primfeed) RequestContext(ctx context.Context, method string, path string, data interface{}, headers map[string]string, target interface{}) error {
+	var payload []byte
 
 	if data != nil {
 		jsonData, err := json.Marshal(data)
@@ -285,59 +409,118 @@ func (p *Primfeed) Request(method string, path string, data interface{}, headers
 			return err
 		}
 
-		body = bytes.NewBuffer(jsonData)
-	} else {
-		body = &bytes.Buffer{}
+		payload = jsonData
 	}
 
-	req, err := http.NewRequest(method, path, body)
-
+	respBody, err := p.fetch(ctx, &call{
+		method:  method,
+		url:     path,
+		payload: payload,
+		headers: headers,
+	})
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.Token))
+	if len(respBody) == 0 {
+		return nil
+	}
+
+	if target != nil {
+		err := json.Unmarshal(respBody, target)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call is one logical API request. do may send it more than once.
+type call struct {
+	method  string
+	url     string
+	payload []byte
+	headers map[string]string
+
+	// body, when set, is streamed instead of payload. It can only be sent
+	// once, so the call is never retried.
+	body        io.Reader
+	contentType string
+
+	// status and header are set by send from the last response, for the
+	// response cache.
+	status int
+	header http.Header
+
+	// revalidating is set when the response cache added the validators,
+	// the only case where a 304 is a success.
+	revalidating bool
+}
+
+// send performs a single HTTP round trip and returns the response body.
+func (p *Primfeed) send(ctx context.Context, c *call, attempt int) ([]byte, error) {
+	start := time.Now()
+	var body io.Reader = bytes.NewReader(c.payload)
+	if c.body != nil {
+		body = c.body
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.CurrentToken()))
 	req.Header.Set("Content-Type", "application/json")
+	if c.contentType != "" {
+		req.Header.Set("Content-Type", c.contentType)
+	}
+
+	if p.userAgent != "" {
+		req.Header.Set("User-Agent", p.userAgent)
+	}
 
-	for key, value := range headers {
+	for key, value := range c.headers {
 		req.Header.Set(key, value)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := p.doer().Do(req)
 	if err != nil {
-		return err
+		p.logAttempt(ctx, c, attempt, 0, time.Since(start), 0, err)
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("failed to fetch data: %s", resp.Status)
-	}
-
 	respBody, err := io.ReadAll(resp.Body)
+	p.logAttempt(ctx, c, attempt, resp.StatusCode, time.Since(start), len(respBody), err)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if len(respBody) == 0 {
-		return nil
+	c.status, c.header = resp.StatusCode, resp.Header
+
+	if resp.StatusCode == http.StatusNotModified && c.revalidating {
+		return respBody, nil
 	}
 
-	if target != nil {
-		err := json.Unmarshal(respBody, target)
-		if err != nil {
-			return err
-		}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(c.method, c.url, resp, respBody)
 	}
-	return nil
+
+	return respBody, nil
 }
 
 func (p *Primfeed) GetUserFollowers(username string) (Followers, error) {
+	return p.GetUserFollowersContext(context.Background(), username)
+}
+
+func (p *Primfeed) GetUserFollowersContext(ctx context.Context, username string) (Followers, error) {
 	url := fmt.Sprintf("%s/entity/%s/followers", p.BaseURL, username)
 	var followers Followers
 
-	if err := p.Request("GET", url, nil, nil, &followers); err != nil {
+	if err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &followers); err != nil {
 		return nil, err
 	}
 
@@ -345,10 +528,14 @@ func (p *Primfeed) GetUserFollowers(username string) (Followers, error) {
 }
 
 func (p *Primfeed) GetUserFollows(username string) (Followers, error) {
+	return p.GetUserFollowsContext(context.Background(), username)
+}
+
+func (p *Primfeed) GetUserFollowsContext(ctx context.Context, username string) (Followers, error) {
 	url := fmt.Sprintf("%s/entity/%s/followed", p.BaseURL, username)
 	var following Followers
 
-	if err := p.Request("GET", url, nil, nil, &following); err != nil {
+	if err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &following); err != nil {
 		return nil, err
 	}
 
@@ -356,135 +543,196 @@ func (p *Primfeed) GetUserFollows(username string) (Followers, error) {
 }
 
 func (p *Primfeed) IsFollowingUser(username string, user string) (bool, error) {
-	var followers Followers
-
-	followers, err := p.GetUserFollows(username)
+	return p.IsFollowingUserContext(context.Background(), username, user)
+}
 
+// IsFollowingUserContext reports whether username follows user, given as
+// an ID or a case-insensitive handle. The follow list of username is
+// cached for a minute by default; pass WithFollowCacheTTL(-1) to
+// NewPrimfeed to download it on every call.
+func (p *Primfeed) IsFollowingUserContext(ctx context.Context, username string, user string) (bool, error) {
+	follows, err := p.follows(ctx, username)
 	if err != nil {
 		return false, err
 	}
 
-	is_following := false
-
-	for _, f := range followers {
-		if strings.ToLower(f.Handle) == strings.ToLower(user) {
-			is_following = true
-			break
-		}
-	}
-
-	return is_following, nil
+	return follows.has(user), nil
 }
 
 func (p *Primfeed) GetMe() error {
+	return p.GetMeContext(context.Background())
+}
+
+// GetMeContext loads the profile, followers and follows in turn. It stops
+// at the first step that fails, including when ctx is done, and leaves Me
+// untouched in that case.
+func (p *Primfeed) GetMeContext(ctx context.Context) error {
+	// Following and unfollowing change what this loads, and the response
+	// cache can't tell, so none of it is cached.
+	ctx = WithoutCache(ctx)
+
 	var profile Profile
 	url := fmt.Sprintf("%s/me", p.BaseURL)
 
-	err := p.Request("GET", url, nil, nil, &profile)
+	err := p.RequestContext(ctx, "GET", url, nil, nil, &profile)
 
 	if err != nil {
-		return fmt.Errorf("could not get profile %v", err)
+		return fmt.Errorf("could not get profile: %w", err)
 	}
 
-	followers, err := p.GetUserFollowers(profile.User.Handle)
+	followers, err := p.GetAllFollowers(ctx, profile.User.Handle)
 	if err != nil {
-		return fmt.Errorf("could not get followers %v", err)
+		return fmt.Errorf("could not get followers: %w", err)
 	}
 
-	follows, err := p.GetUserFollows(profile.User.Handle)
+	follows, err := p.GetAllFollows(ctx, profile.User.Handle)
 	if err != nil {
-		return fmt.Errorf("could not get follows %v", err)
+		return fmt.Errorf("could not get follows: %w", err)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
 	p.Me.Profile = profile
 	p.Me.Followers = followers
 	p.Me.Following = follows
+	p.mu.Unlock()
 
 	return nil
 }
 
 func (p *Primfeed) GetUserProfile(username string) (UserProfile, error) {
+	return p.GetUserProfileContext(context.Background(), username)
+}
+
+func (p *Primfeed) GetUserProfileContext(ctx context.Context, username string) (UserProfile, error) {
 	var profile UserProfile
 	url := fmt.Sprintf("%s/entity/%s", p.BaseURL, username)
 
-	err := p.Request("GET", url, nil, nil, &profile)
+	// Profiles carry follow state, which follows elsewhere change.
+	err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &profile)
 
 	if err != nil {
-		return profile, fmt.Errorf("could not get profile %v", err)
+		if errors.Is(err, ErrNotFound) {
+			p.handles.Invalidate(username)
+		}
+
+		return profile, fmt.Errorf("could not get profile: %w", err)
 	}
 
+	p.handles.Put(username, profile.User)
+
 	return profile, nil
 }
 
 func (p *Primfeed) FollowUser(username string) error {
-	profile, err := p.GetUserProfile(username)
-	if err != nil {
-		return fmt.Errorf("error getting profile to follow: %v", err)
+	return p.FollowUserContext(context.Background(), username)
+}
+
+func (p *Primfeed) FollowUserContext(ctx context.Context, username string) error {
+	_, resolved, err := p.actOnHandle(ctx, username, p.FollowByIdContext)
+	if err != nil && !resolved {
+		return fmt.Errorf("error getting profile to follow: %w", err)
 	}
 
-	return p.FollowById(profile.ID)
+	return err
 }
 
 func (p *Primfeed) FollowById(id string) error {
+	return p.FollowByIdContext(context.Background(), id)
+}
+
+func (p *Primfeed) FollowByIdContext(ctx context.Context, id string) error {
 	url := fmt.Sprintf("%s/follow/%s", p.BaseURL, id)
 
-	err := p.Request("POST", url, nil, nil, nil)
+	err := p.RequestContext(ctx, "POST", url, nil, nil, nil)
 	if err != nil {
-		return fmt.Errorf("error following user: %v", err)
+		return fmt.Errorf("error following user: %w", err)
 	}
 
+	p.clearFollowCache()
+
 	return nil
 }
 
 func (p *Primfeed) UnfollowUser(username string) error {
-	profile, err := p.GetUserProfile(username)
-	if err != nil {
-		return fmt.Errorf("error unfollowing user: %v", err)
+	return p.UnfollowUserContext(context.Background(), username)
+}
+
+func (p *Primfeed) UnfollowUserContext(ctx context.Context, username string) error {
+	_, resolved, err := p.actOnHandle(ctx, username, p.UnfollowByIdContext)
+	if err != nil && !resolved {
+		return fmt.Errorf("error unfollowing user: %w", err)
 	}
 
-	return p.UnfollowById(profile.ID)
+	return err
 }
 
 func (p *Primfeed) UnfollowById(id string) error {
+	return p.UnfollowByIdContext(context.Background(), id)
+}
+
+func (p *Primfeed) UnfollowByIdContext(ctx context.Context, id string) error {
 	url := fmt.Sprintf("%s/follow/%s", p.BaseURL, id)
 
-	err := p.Request("DELETE", url, nil, nil, nil)
+	err := p.RequestContext(ctx, "DELETE", url, nil, nil, nil)
 	if err != nil {
 		return err
 	}
 
+	p.clearFollowCache()
+
 	return nil
 }
 
 func (p *Primfeed) UpdateProfile(profile interface{}) error {
-	url := fmt.Sprintf("%s/entity/%s", p.BaseURL, p.Me.Profile.User.Handle)
+	return p.UpdateProfileContext(context.Background(), profile)
+}
+
+func (p *Primfeed) UpdateProfileContext(ctx context.Context, profile interface{}) error {
+	url := fmt.Sprintf("%s/entity/%s", p.BaseURL, p.handle())
 
-	err := p.Request("PATCH", url, profile, nil, nil)
+	err := p.RequestContext(ctx, "PATCH", url, profile, nil, nil)
 	if err != nil {
-		return fmt.Errorf("could not update profile: %v", err)
+		return fmt.Errorf("could not update profile: %w", err)
 	}
 
 	return nil
 }
 
 func (p *Primfeed) GetNotifications() (NotificationsResponse, error) {
+	return p.GetNotificationsContext(context.Background())
+}
+
+func (p *Primfeed) GetNotificationsContext(ctx context.Context) (NotificationsResponse, error) {
 	var notificationResponse NotificationsResponse
 	url := fmt.Sprintf("%s/notifications", p.BaseURL)
 
-	err := p.Request("GET", url, nil, nil, &notificationResponse)
+	// Notifications change with every read, like and follow, so they
+	// always skip the response cache.
+	err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &notificationResponse)
 	if err != nil {
-		return NotificationsResponse{}, fmt.Errorf("error could not get notifications: %v", err)
+		return NotificationsResponse{}, fmt.Errorf("error could not get notifications: %w", err)
 	}
 
-	p.Me.Notifications = notificationResponse
+	p.mu.Lock()
+	p.Me.Notifications = cloneNotifications(notificationResponse)
+	p.mu.Unlock()
+
 	return notificationResponse, nil
 }
 
 func (p *Primfeed) GetNotificationCount() (int, error) {
+	return p.GetNotificationCountContext(context.Background())
+}
+
+func (p *Primfeed) GetNotificationCountContext(ctx context.Context) (int, error) {
 	url := fmt.Sprintf("%s/notifications/count", p.BaseURL)
 
 	var count int
-	err := p.Request("GET", url, nil, nil, &count)
+	err := p.RequestContext(WithoutCache(ctx), "GET", url, nil, nil, &count)
 	if err != nil {
 		return count, err
 	}
@@ -493,28 +741,38 @@ func (p *Primfeed) GetNotificationCount() (int, error) {
 }
 
 func (p *Primfeed) Like(post string) error {
-	url := fmt.Sprintf("%s/pf/post/%s/like", p.BaseURL, post)
-
-	err := p.Request("POST", url, nil, nil, nil)
-	if err != nil {
-		return fmt.Errorf("could not like post: %v", err)
-	}
+	return p.LikeContext(context.Background(), post)
+}
 
-	return nil
+// LikeContext likes post. Liking a post that is already liked does
+// nothing, see SetLiked.
+func (p *Primfeed) LikeContext(ctx context.Context, post string) error {
+	_, err := p.SetLiked(ctx, post, true)
+	return err
 }
 
 func (p *Primfeed) UnLike(post string) error {
-	return p.Like(post)
+	return p.UnLikeContext(context.Background(), post)
+}
+
+// UnLikeContext removes our like from post, if there is one.
+func (p *Primfeed) UnLikeContext(ctx context.Context, post string) error {
+	_, err := p.SetLiked(ctx, post, false)
+	return err
 }
 
 func (p *Primfeed) GetFeed(id string, page int) (FeedResponse, error) {
+	return p.GetFeedContext(context.Background(), id, page)
+}
+
+func (p *Primfeed) GetFeedContext(ctx context.Context, id string, page int) (FeedResponse, error) {
 	url := fmt.Sprintf("%s/pf/%s/feed?page=%d", p.BaseURL, id, page)
 
 	var feedResponse FeedResponse
 
-	err := p.Request("GET", url, nil, nil, &feedResponse)
+	err := p.RequestContext(ctx, "GET", url, nil, nil, &feedResponse)
 	if err != nil {
-		return FeedResponse{}, fmt.Errorf("could not load feed: %v", err)
+		return FeedResponse{}, fmt.Errorf("could not load feed: %w", err)
 	}
 
 	return feedResponse, nil
@@ -1,10 +1,12 @@
 package primfeed
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -95,3 +97,28 @@ func TestGetUserFollows(t *testing.T) {
 	assert.Equal(t, "Test User", followsResponse[0].Name)
 	assert.Equal(t, "testuser", followsResponse[0].Handle)
 }
+
+func TestGetMeContextCancelled(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	pf := NewPrimfeed(mockServer.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := pf.GetMeContext(ctx)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+	assert.Empty(t, pf.Me.Profile.User.Handle)
+}
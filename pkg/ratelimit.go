@@ -0,0 +1,131 @@
+package primfeed
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// EndpointGroup buckets endpoints so writes can be throttled harder than
+// reads.
+type EndpointGroup int
+
+const (
+	// ReadEndpoints are GET requests such as GetFeed or GetUserProfile.
+	ReadEndpoints EndpointGroup = iota
+	// WriteEndpoints are everything else, e.g. FollowById or Like.
+	WriteEndpoints
+)
+
+func endpointGroup(method string) EndpointGroup {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ReadEndpoints
+	}
+
+	return WriteEndpoints
+}
+
+// RateLimiter is a token bucket. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows rps requests per second on average, with bursts of
+// up to burst requests.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return ctx.Err()
+	}
+
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long
+// the caller has to wait for that token to be available.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token that was reserved but never used.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+}
+
+// WithRateLimit limits every request made by the client.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(p *Primfeed) {
+		p.limiter = NewRateLimiter(rps, burst)
+	}
+}
+
+// WithEndpointRateLimit adds a limit for one group of endpoints, on top of
+// any client wide limit.
+func WithEndpointRateLimit(group EndpointGroup, rps float64, burst int) Option {
+	return func(p *Primfeed) {
+		if p.groupLimiters == nil {
+			p.groupLimiters = make(map[EndpointGroup]*RateLimiter)
+		}
+
+		p.groupLimiters[group] = NewRateLimiter(rps, burst)
+	}
+}
+
+// wait blocks until the client and endpoint group limiters allow method.
+func (p *Primfeed) wait(ctx context.Context, method string) error {
+	if err := p.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return p.groupLimiters[endpointGroup(method)].Wait(ctx)
+}
@@ -0,0 +1,62 @@
+package primfeed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	// Arrange
+	limiter := NewRateLimiter(10, 2)
+	now := limiter.last
+
+	// Act & Assert
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	assert.Equal(t, time.Duration(0), limiter.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(now.Add(100*time.Millisecond)))
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	// Arrange
+	limiter := NewRateLimiter(0.1, 1)
+	limiter.reserve(time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := limiter.Wait(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWriteEndpointRateLimit(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithEndpointRateLimit(WriteEndpoints, 20, 1))
+
+	// Act
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, pf.FollowById("123"))
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
@@ -0,0 +1,173 @@
+package primfeed
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const defaultFollowCacheTTL = time.Minute
+
+// followIndex looks up entities by ID or by case-insensitive handle.
+type followIndex struct {
+	byID     map[string]Follower
+	byHandle map[string]Follower
+}
+
+func newFollowIndex(list Followers) followIndex {
+	idx := followIndex{
+		byID:     make(map[string]Follower, len(list)),
+		byHandle: make(map[string]Follower, len(list)),
+	}
+
+	for _, f := range list {
+		idx.byID[f.ID] = f
+		idx.byHandle[strings.ToLower(f.Handle)] = f
+	}
+
+	return idx
+}
+
+func (idx followIndex) has(idOrHandle string) bool {
+	if _, ok := idx.byID[idOrHandle]; ok {
+		return true
+	}
+
+	_, ok := idx.byHandle[strings.ToLower(idOrHandle)]
+	return ok
+}
+
+// Relationships answers questions about who follows whom from one load of
+// an account's followers and follows.
+type Relationships struct {
+	Followers Followers
+	Following Followers
+
+	followers followIndex
+	following followIndex
+}
+
+func NewRelationships(followers Followers, following Followers) *Relationships {
+	return &Relationships{
+		Followers: followers,
+		Following: following,
+		followers: newFollowIndex(followers),
+		following: newFollowIndex(following),
+	}
+}
+
+// LoadRelationships fetches every follower and follow of username.
+func (p *Primfeed) LoadRelationships(ctx context.Context, username string) (*Relationships, error) {
+	followers, err := p.GetAllFollowers(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	following, err := p.GetAllFollows(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRelationships(followers, following), nil
+}
+
+// MyRelationships builds Relationships from what GetMe loaded, without
+// any request.
+func (p *Primfeed) MyRelationships() *Relationships {
+	me := p.Snapshot()
+	return NewRelationships(me.Followers, me.Following)
+}
+
+// IsFollowing reports whether the account follows the given ID or handle.
+func (r *Relationships) IsFollowing(idOrHandle string) bool {
+	return r.following.has(idOrHandle)
+}
+
+// IsFollowedBy reports whether the given ID or handle follows the account.
+func (r *Relationships) IsFollowedBy(idOrHandle string) bool {
+	return r.followers.has(idOrHandle)
+}
+
+// Mutuals are the entities that follow the account and are followed back.
+func (r *Relationships) Mutuals() Followers {
+	return filter(r.Following, func(f Follower) bool { return r.IsFollowedBy(f.ID) })
+}
+
+// NotFollowingBack are the entities the account follows that don't follow
+// it back.
+func (r *Relationships) NotFollowingBack() Followers {
+	return filter(r.Following, func(f Follower) bool { return !r.IsFollowedBy(f.ID) })
+}
+
+// Fans are the entities following the account that it doesn't follow back.
+func (r *Relationships) Fans() Followers {
+	return filter(r.Followers, func(f Follower) bool { return !r.IsFollowing(f.ID) })
+}
+
+func filter(list Followers, keep func(Follower) bool) Followers {
+	var kept Followers
+	for _, f := range list {
+		if keep(f) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
+
+type cachedFollows struct {
+	index    followIndex
+	loadedAt time.Time
+}
+
+// WithFollowCacheTTL sets how long IsFollowingUser reuses a downloaded
+// follow list. Zero keeps the default of one minute and a negative ttl
+// disables the cache. Following or unfollowing through the client clears it.
+func WithFollowCacheTTL(ttl time.Duration) Option {
+	return func(p *Primfeed) {
+		p.followCacheTTL = ttl
+	}
+}
+
+// follows returns the follow index of username, downloading it when it
+// isn't cached or has expired.
+func (p *Primfeed) follows(ctx context.Context, username string) (followIndex, error) {
+	key := strings.ToLower(username)
+	ttl := p.followCacheTTL
+	if ttl == 0 {
+		ttl = defaultFollowCacheTTL
+	}
+
+	p.mu.RLock()
+	cached, ok := p.followCache[key]
+	p.mu.RUnlock()
+
+	if ok && time.Since(cached.loadedAt) < ttl {
+		return cached.index, nil
+	}
+
+	following, err := p.GetAllFollows(ctx, username)
+	if err != nil {
+		return followIndex{}, err
+	}
+
+	idx := newFollowIndex(following)
+	if ttl < 0 {
+		return idx, nil
+	}
+
+	p.mu.Lock()
+	if p.followCache == nil {
+		p.followCache = make(map[string]cachedFollows)
+	}
+	p.followCache[key] = cachedFollows{index: idx, loadedAt: time.Now()}
+	p.mu.Unlock()
+
+	return idx, nil
+}
+
+func (p *Primfeed) clearFollowCache() {
+	p.mu.Lock()
+	p.followCache = nil
+	p.mu.Unlock()
+}
@@ -0,0 +1,119 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func handles(list Followers) []string {
+	var out []string
+	for _, f := range list {
+		out = append(out, f.Handle)
+	}
+
+	return out
+}
+
+func TestRelationships(t *testing.T) {
+	// Arrange
+	followers := Followers{follower("1", "Mutual"), follower("2", "fan")}
+	following := Followers{follower("1", "Mutual"), follower("3", "idol")}
+
+	// Act
+	r := NewRelationships(followers, following)
+
+	// Assert
+	assert.Equal(t, []string{"Mutual"}, handles(r.Mutuals()))
+	assert.Equal(t, []string{"idol"}, handles(r.NotFollowingBack()))
+	assert.Equal(t, []string{"fan"}, handles(r.Fans()))
+	assert.True(t, r.IsFollowing("mutual"))
+	assert.True(t, r.IsFollowing("3"))
+	assert.False(t, r.IsFollowing("fan"))
+	assert.True(t, r.IsFollowedBy("FAN"))
+}
+
+func TestIsFollowingUserCachesFollows(t *testing.T) {
+	// Arrange
+	var listCalls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/testuser/followed":
+			listCalls++
+			fmt.Fprint(w, `[{"id": "1", "handle": "OtherTestUser"}]`)
+		case "/follow/2":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	first, err := pf.IsFollowingUser("testuser", "othertestuser")
+	assert.NoError(t, err)
+	second, err := pf.IsFollowingUser("testuser", "someoneelse")
+	assert.NoError(t, err)
+	callsBeforeFollow := listCalls
+	assert.NoError(t, pf.FollowById("2"))
+	_, err = pf.IsFollowingUser("testuser", "othertestuser")
+	assert.NoError(t, err)
+
+	// Assert
+	assert.True(t, first)
+	assert.False(t, second)
+	assert.Equal(t, 2, callsBeforeFollow)
+	assert.Equal(t, 4, listCalls)
+}
+
+func TestIsFollowingUserWithoutFollowCache(t *testing.T) {
+	// Arrange
+	var listCalls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/entity/testuser/followed" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		listCalls++
+		fmt.Fprint(w, `[{"id": "1", "handle": "OtherTestUser"}]`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL, WithFollowCacheTTL(-1))
+
+	// Act
+	_, err := pf.IsFollowingUser("testuser", "othertestuser")
+	assert.NoError(t, err)
+	_, err = pf.IsFollowingUser("testuser", "othertestuser")
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 4, listCalls)
+}
+
+func TestLoadRelationships(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/entity/testuser/followers":
+			fmt.Fprint(w, `[{"id": "1", "handle": "one"}, {"id": "2", "handle": "two"}]`)
+		case "/entity/testuser/followed":
+			fmt.Fprint(w, `[{"id": "2", "handle": "two"}]`)
+		}
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	// Act
+	r, err := pf.LoadRelationships(context.Background(), "testuser")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"two"}, handles(r.Mutuals()))
+	assert.Equal(t, []string{"one"}, handles(r.Fans()))
+}
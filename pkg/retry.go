@@ -0,0 +1,165 @@
+package primfeed
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only idempotent
+// requests are retried: Like toggles the like state, so replaying a POST
+// could undo what the first attempt did.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry. It doubles on each
+	// further attempt, up to MaxDelay, and is jittered.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// RetryableStatus lists the HTTP statuses worth retrying. Transport
+	// errors are always retried.
+	RetryableStatus []int
+
+	// Idempotent reports whether a request may be sent more than once.
+	// Defaults to IsIdempotent.
+	Idempotent func(method string, path string) bool
+
+	// OnRetry is called before sleeping ahead of each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a retry that is about to happen.
+type RetryEvent struct {
+	Method  string
+	URL     string
+	Attempt int
+	Wait    time.Duration
+	Err     error
+}
+
+// DefaultRetryPolicy retries up to three times on rate limits and gateway
+// errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetry enables retries. Requests are sent once when it is not set.
+func WithRetry(policy RetryPolicy) Option {
+	return func(p *Primfeed) {
+		p.retry = &policy
+	}
+}
+
+// IsIdempotent reports whether sending the request twice has the same
+// effect as sending it once. GET and DELETE (e.g. unfollowing) are safe;
+// POST and PATCH are not, since endpoints such as /pf/post/{id}/like
+// toggle state.
+func IsIdempotent(method string, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// do sends the request, retrying according to the client's RetryPolicy.
+func (p *Primfeed) do(ctx context.Context, c *call) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		if err := p.wait(ctx, c.method); err != nil {
+			return nil, err
+		}
+
+		body, err := p.send(ctx, c, attempt)
+		if err == nil {
+			return body, nil
+		}
+
+		wait, ok := p.retry.backoff(ctx, c.method, c.url, attempt, err)
+		if !ok || c.body != nil {
+			return nil, err
+		}
+
+		if p.retry.OnRetry != nil {
+			p.retry.OnRetry(RetryEvent{
+				Method:  c.method,
+				URL:     c.url,
+				Attempt: attempt,
+				Wait:    wait,
+				Err:     err,
+			})
+		}
+
+		if !sleep(ctx, wait) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff decides whether the failed attempt should be retried and how long
+// to wait first.
+func (r *RetryPolicy) backoff(ctx context.Context, method string, path string, attempt int, err error) (time.Duration, bool) {
+	if r == nil || attempt >= r.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	idempotent := r.Idempotent
+	if idempotent == nil {
+		idempotent = IsIdempotent
+	}
+
+	if !idempotent(method, path) {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		retryable := false
+		for _, status := range r.RetryableStatus {
+			if status == apiErr.StatusCode {
+				retryable = true
+				break
+			}
+		}
+
+		if !retryable {
+			return 0, false
+		}
+
+		retryAfter = apiErr.RetryAfter
+	}
+
+	// A zero BaseDelay retries straight away. Otherwise clamp to MaxDelay,
+	// including when the shift overflowed.
+	wait := r.BaseDelay << (attempt - 1)
+	if r.BaseDelay > 0 && r.MaxDelay > 0 && (wait <= 0 || wait > r.MaxDelay || wait>>(attempt-1) != r.BaseDelay) {
+		wait = r.MaxDelay
+	}
+
+	// Equal jitter: keep half the delay, randomise the other half.
+	if wait > 0 {
+		wait = wait/2 + rand.N(wait/2+1)
+	}
+
+	if retryAfter > wait {
+		wait = retryAfter
+	}
+
+	return wait, true
+}
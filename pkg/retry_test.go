@@ -0,0 +1,107 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(events *[]RetryEvent) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	policy.OnRetry = func(e RetryEvent) {
+		*events = append(*events, e)
+	}
+
+	return policy
+}
+
+func TestRetryRecoversFromBadGateway(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		fmt.Fprint(w, `[{"id": "123", "handle": "othertestuser"}]`)
+	}))
+	defer mockServer.Close()
+
+	var events []RetryEvent
+	pf := NewPrimfeed(mockServer.URL, WithRetry(testRetryPolicy(&events)))
+
+	// Act
+	followers, err := pf.GetUserFollowers("testuser")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, followers, 1)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, events, 2)
+	assert.Equal(t, 2, events[1].Attempt)
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer mockServer.Close()
+
+	var events []RetryEvent
+	pf := NewPrimfeed(mockServer.URL, WithRetry(testRetryPolicy(&events)))
+
+	// Act
+	err := pf.FollowById("123")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, events)
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	// Arrange
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	err := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}
+
+	// Act
+	wait, ok := policy.backoff(context.Background(), "DELETE", "/follow/123", 1, err)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, wait)
+}
+
+func TestRetryBackoffDelays(t *testing.T) {
+	// Arrange
+	immediate := DefaultRetryPolicy()
+	immediate.BaseDelay = 0
+	capped := DefaultRetryPolicy()
+	capped.MaxAttempts = 100
+	err := &APIError{StatusCode: http.StatusBadGateway}
+	ctx := context.Background()
+
+	// Act
+	zeroWait, zeroOk := immediate.backoff(ctx, "GET", "/me", 1, err)
+	cappedWait, cappedOk := capped.backoff(ctx, "GET", "/me", 80, err)
+
+	// Assert
+	assert.True(t, zeroOk)
+	assert.Equal(t, time.Duration(0), zeroWait)
+	assert.True(t, cappedOk)
+	assert.LessOrEqual(t, cappedWait, capped.MaxDelay)
+	assert.GreaterOrEqual(t, cappedWait, capped.MaxDelay/2)
+}
@@ -0,0 +1,119 @@
+package primfeed
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FollowerSnapshot is the follower list of an account at a point in time,
+// meant to be saved and compared against a later list with Diff.
+type FollowerSnapshot struct {
+	Handle    string    `json:"handle"`
+	TakenAt   time.Time `json:"takenAt"`
+	Followers Followers `json:"followers"`
+}
+
+// FollowerDiff is what changed between a snapshot and a newer follower
+// list. Followers are matched by ID, so a handle change shows up in
+// Renamed rather than as an unfollow and a follow.
+type FollowerDiff struct {
+	Added   Followers
+	Removed Followers
+	Renamed []HandleChange
+}
+
+type HandleChange struct {
+	ID   string
+	From string
+	To   string
+}
+
+func NewFollowerSnapshot(handle string, followers Followers) FollowerSnapshot {
+	return FollowerSnapshot{
+		Handle:    handle,
+		TakenAt:   time.Now().UTC(),
+		Followers: followers,
+	}
+}
+
+// SnapshotFollowers snapshots the followers loaded by GetMe.
+func (p *Primfeed) SnapshotFollowers() FollowerSnapshot {
+	me := p.Snapshot()
+	return NewFollowerSnapshot(me.Profile.User.Handle, me.Followers)
+}
+
+func LoadFollowerSnapshot(path string) (FollowerSnapshot, error) {
+	var snapshot FollowerSnapshot
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, err
+	}
+
+	err = json.Unmarshal(data, &snapshot)
+	return snapshot, err
+}
+
+func (s FollowerSnapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data next to path and renames it into place, so
+// a crash never leaves a half written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Diff compares the snapshot with a newer follower list, e.g. a fresh
+// GetAllFollowers result.
+func (s FollowerSnapshot) Diff(current Followers) FollowerDiff {
+	var diff FollowerDiff
+
+	before := make(map[string]Follower, len(s.Followers))
+	for _, f := range s.Followers {
+		before[f.ID] = f
+	}
+
+	now := make(map[string]bool, len(current))
+	for _, f := range current {
+		now[f.ID] = true
+
+		old, ok := before[f.ID]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, f)
+		case old.Handle != f.Handle:
+			diff.Renamed = append(diff.Renamed, HandleChange{ID: f.ID, From: old.Handle, To: f.Handle})
+		}
+	}
+
+	for _, f := range s.Followers {
+		if !now[f.ID] {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+
+	return diff
+}
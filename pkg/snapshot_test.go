@@ -0,0 +1,43 @@
+package primfeed
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func follower(id string, handle string) Follower {
+	var f Follower
+	f.ID = id
+	f.Handle = handle
+	return f
+}
+
+func TestFollowerSnapshotDiff(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "followers.json")
+	before := NewFollowerSnapshot("testuser", Followers{
+		follower("1", "one"),
+		follower("2", "two"),
+		follower("3", "three"),
+	})
+	assert.NoError(t, before.Save(path))
+
+	current := Followers{
+		follower("1", "one"),
+		follower("3", "three-renamed"),
+		follower("4", "four"),
+	}
+
+	// Act
+	loaded, err := LoadFollowerSnapshot(path)
+	diff := loaded.Diff(current)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", loaded.Handle)
+	assert.Equal(t, Followers{follower("4", "four")}, diff.Added)
+	assert.Equal(t, Followers{follower("2", "two")}, diff.Removed)
+	assert.Equal(t, []HandleChange{{ID: "3", From: "three", To: "three-renamed"}}, diff.Renamed)
+}
@@ -0,0 +1,348 @@
+package primfeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+const (
+	defaultWatchInterval    = 30 * time.Second
+	defaultWatchMaxInterval = 5 * time.Minute
+
+	// maxSeenNotifications bounds the cursor; older IDs are forgotten.
+	maxSeenNotifications = 1000
+)
+
+// NotificationEvent is a notification the watcher hasn't seen before,
+// along with the group it came in.
+type NotificationEvent struct {
+	Group        Notification
+	Notification SubNotification
+}
+
+type WatchOptions struct {
+	// Interval between polls. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// MaxInterval caps the backoff: each poll that finds nothing new
+	// doubles the interval up to MaxInterval, and the first new
+	// notification resets it. Defaults to 5 minutes.
+	MaxInterval time.Duration
+
+	// Cursor persists the IDs already emitted so a restarted watcher
+	// doesn't replay them. Optional.
+	Cursor CursorStore
+
+	// Replay emits the notifications that already exist when the watcher
+	// starts with no cursor (or an empty one). By default, like WatchFeed,
+	// the first poll only records them and later polls emit what's new.
+	Replay bool
+
+	// OnError receives poll errors. The watcher keeps polling after them.
+	OnError func(error)
+}
+
+// CursorStore persists the notification IDs a watcher has already seen.
+type CursorStore interface {
+	LoadCursor() ([]string, error)
+	SaveCursor(ids []string) error
+}
+
+// FileCursorStore keeps the cursor in a JSON file.
+type FileCursorStore struct {
+	Path string
+}
+
+func (s FileCursorStore) LoadCursor() ([]string, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (s FileCursorStore) SaveCursor(ids []string) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.Path, data)
+}
+
+// WatchNotifications polls for notifications and sends the new ones on the
+// first channel, oldest first. Notifications that already exist when it
+// starts without a cursor are skipped unless opts.Replay is set. Poll errors
+// go to the second channel and are dropped if nobody is receiving. Both
+// channels are closed once ctx is done.
+func (p *Primfeed) WatchNotifications(ctx context.Context, opts WatchOptions) (<-chan NotificationEvent, <-chan error) {
+	events := make(chan NotificationEvent)
+	errs := make(chan error, 1)
+
+	onError := opts.OnError
+	opts.OnError = func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		err := p.WatchNotificationsFunc(ctx, opts, func(event NotificationEvent) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			opts.OnError(err)
+		}
+	}()
+
+	return events, errs
+}
+
+// WatchNotificationsFunc is like WatchNotifications but calls fn for each
+// new notification. fn reports whether it handled the event; returning
+// false stops the watcher, and that event and the rest of its batch stay
+// out of the cursor. It blocks until ctx is done or fn returns false, or
+// returns straight away if the cursor can't be loaded.
+func (p *Primfeed) WatchNotificationsFunc(ctx context.Context, opts WatchOptions, fn func(NotificationEvent) bool) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWatchMaxInterval
+	}
+	maxInterval = max(maxInterval, interval)
+
+	var cursor []string
+	if opts.Cursor != nil {
+		ids, err := opts.Cursor.LoadCursor()
+		if err != nil {
+			return err
+		}
+		cursor = ids
+	}
+
+	seen := make(map[string]bool, len(cursor))
+	for _, id := range cursor {
+		seen[id] = true
+	}
+
+	seeded := len(cursor) > 0 || opts.Replay
+
+	wait := interval
+	for {
+		fresh, err := p.pollNotifications(ctx, seen)
+		if err != nil && opts.OnError != nil && ctx.Err() == nil {
+			opts.OnError(err)
+		}
+
+		emitted, stopped := false, false
+		added := 0
+		for _, event := range fresh {
+			if seeded {
+				if ctx.Err() != nil || !fn(event) {
+					stopped = true
+					break
+				}
+				emitted = true
+			}
+
+			seen[event.Notification.ID] = true
+			cursor = append(cursor, event.Notification.ID)
+			added++
+		}
+
+		if err == nil {
+			seeded = true
+		}
+
+		if added > 0 {
+			if len(cursor) > maxSeenNotifications {
+				for _, id := range cursor[:len(cursor)-maxSeenNotifications] {
+					delete(seen, id)
+				}
+				cursor = append([]string(nil), cursor[len(cursor)-maxSeenNotifications:]...)
+			}
+
+			if opts.Cursor != nil {
+				if err := opts.Cursor.SaveCursor(cursor); err != nil && opts.OnError != nil {
+					opts.OnError(err)
+				}
+			}
+		}
+
+		if stopped {
+			return ctx.Err()
+		}
+
+		if emitted {
+			wait = interval
+		} else {
+			wait = min(wait*2, maxInterval)
+		}
+
+		if !sleep(ctx, wait) {
+			return ctx.Err()
+		}
+	}
+}
+
+// pollNotifications returns the notifications not in seen, oldest first.
+// The API lists the newest first.
+func (p *Primfeed) pollNotifications(ctx context.Context, seen map[string]bool) ([]NotificationEvent, error) {
+	resp, err := p.GetNotificationsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []NotificationEvent
+	added := make(map[string]bool)
+	for i := len(resp.Notifications) - 1; i >= 0; i-- {
+		group := resp.Notifications[i]
+		for j := len(group.Notifications) - 1; j >= 0; j-- {
+			sub := group.Notifications[j]
+			if !seen[sub.ID] && !added[sub.ID] {
+				added[sub.ID] = true
+				fresh = append(fresh, NotificationEvent{Group: group, Notification: sub})
+			}
+		}
+	}
+
+	return fresh, nil
+}
+
+// sleep waits for d, returning false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// WatchFeed polls the feed of the entity id every interval and sends posts
+// published since the watcher started, oldest first. Each poll walks pages
+// only until it reaches a post it has already seen. Poll errors go to the
+// second channel and are dropped if nobody is receiving. Both channels are
+// closed once ctx is done.
+func (p *Primfeed) WatchFeed(ctx context.Context, id string, interval time.Duration) (<-chan Feed, <-chan error) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	posts := make(chan Feed)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(posts)
+
+		var newest *Post
+		for {
+			fresh, err := p.pollFeed(ctx, id, newest)
+			if err != nil && ctx.Err() == nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			}
+
+			if len(fresh) > 0 {
+				// The first poll only finds where the feed currently ends.
+				if newest != nil {
+					for i := len(fresh) - 1; i >= 0; i-- {
+						select {
+						case posts <- fresh[i]:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+
+				newest = &fresh[0].Data
+			} else if newest == nil && err == nil {
+				newest = &Post{}
+			}
+
+			if !sleep(ctx, interval) {
+				return
+			}
+		}
+	}()
+
+	return posts, errs
+}
+
+// pollFeed returns the posts newer than newest, newest first. With no
+// newest yet, it returns just the latest post.
+func (p *Primfeed) pollFeed(ctx context.Context, id string, newest *Post) ([]Feed, error) {
+	if newest == nil {
+		pager := p.FeedPager(ctx, id, MaxItems(1))
+		if pager.Next() {
+			return []Feed{pager.Item()}, nil
+		}
+
+		return nil, pager.Err()
+	}
+
+	var opts []PagerOption
+	if newest.ID != "" {
+		opts = append(opts, StopAt(newest.ID))
+	}
+
+	var fresh []Feed
+	pager := p.FeedPager(ctx, id, opts...)
+	for pager.Next() {
+		post := pager.Item()
+
+		// Catches up even if the post we stopped at was deleted.
+		if newest.CreatedAt != 0 && post.Data.CreatedAt <= newest.CreatedAt {
+			break
+		}
+
+		fresh = append(fresh, post)
+	}
+
+	// A partial walk would skip the posts on the pages we didn't reach.
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	return fresh, nil
+}
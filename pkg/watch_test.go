@@ -0,0 +1,232 @@
+package primfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchNotifications(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	polls := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		n := polls
+		mu.Unlock()
+
+		if n == 1 {
+			fmt.Fprint(w, `{"notifications": [{"type": "like", "notifications": [{"id": "n2"}, {"id": "n1"}]}]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"notifications": [
+			{"type": "follow", "notifications": [{"id": "n3"}]},
+			{"type": "like", "notifications": [{"id": "n2"}, {"id": "n1"}]}
+		]}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	cursor := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	opts := WatchOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Cursor: cursor, Replay: true}
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, _ := pf.WatchNotifications(ctx, opts)
+	var ids []string
+	for event := range events {
+		ids = append(ids, event.Notification.ID)
+		if len(ids) == 3 {
+			cancel()
+		}
+	}
+
+	// Assert
+	assert.Equal(t, []string{"n1", "n2", "n3"}, ids)
+	saved, err := cursor.LoadCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1", "n2", "n3"}, saved)
+}
+
+func TestWatchNotificationsSkipsBacklog(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	polls := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		n := polls
+		mu.Unlock()
+
+		if n == 1 {
+			fmt.Fprint(w, `{"notifications": [{"type": "like", "notifications": [{"id": "n2"}, {"id": "n1"}]}]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"notifications": [
+			{"type": "follow", "notifications": [{"id": "n3"}]},
+			{"type": "like", "notifications": [{"id": "n2"}, {"id": "n1"}]}
+		]}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	cursor := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	opts := WatchOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Cursor: cursor}
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, _ := pf.WatchNotifications(ctx, opts)
+	var ids []string
+	for event := range events {
+		ids = append(ids, event.Notification.ID)
+		cancel()
+	}
+
+	// Assert
+	assert.Equal(t, []string{"n3"}, ids)
+	saved, err := cursor.LoadCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1", "n2", "n3"}, saved)
+}
+
+func TestWatchNotificationsSavesOnlyDelivered(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"notifications": [{"type": "like", "notifications": [{"id": "n3"}, {"id": "n2"}, {"id": "n1"}]}]}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	cursor := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	opts := WatchOptions{Interval: time.Millisecond, Cursor: cursor, Replay: true}
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, _ := pf.WatchNotifications(ctx, opts)
+	var ids []string
+	for event := range events {
+		ids = append(ids, event.Notification.ID)
+		cancel()
+	}
+
+	// Assert
+	assert.Equal(t, "n1", ids[0])
+	saved, err := cursor.LoadCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, ids, saved)
+}
+
+func TestWatchNotificationsFuncStops(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"notifications": [{"type": "like", "notifications": [{"id": "n3"}, {"id": "n2"}, {"id": "n1"}]}]}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	cursor := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	opts := WatchOptions{Interval: time.Millisecond, Cursor: cursor, Replay: true}
+
+	// Act
+	var ids []string
+	err := pf.WatchNotificationsFunc(context.Background(), opts, func(event NotificationEvent) bool {
+		ids = append(ids, event.Notification.ID)
+		return event.Notification.ID != "n2"
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1", "n2"}, ids)
+	saved, err := cursor.LoadCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n1"}, saved)
+}
+
+func TestWatchNotificationsResumesFromCursor(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"notifications": [{"type": "like", "notifications": [{"id": "n2"}, {"id": "n1"}]}]}`)
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	cursor := FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor.json")}
+	assert.NoError(t, cursor.SaveCursor([]string{"n1"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	var ids []string
+	err := pf.WatchNotificationsFunc(ctx, WatchOptions{Interval: time.Millisecond, Cursor: cursor}, func(event NotificationEvent) bool {
+		ids = append(ids, event.Notification.ID)
+		return true
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"n2"}, ids)
+}
+
+func TestWatchFeed(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	feed := []string{`{"data": {"id": "p2", "createdAt": 2}}`, `{"data": {"id": "p1", "createdAt": 1}}`}
+	firstPoll := make(chan struct{})
+	var once sync.Once
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		defer once.Do(func() { close(firstPoll) })
+
+		// One post per page, newest first.
+		page := 0
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		if page < 1 || page > len(feed) {
+			fmt.Fprint(w, `{"feed": []}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"feed": [%s]}`, feed[page-1])
+	}))
+	defer mockServer.Close()
+	pf := NewPrimfeed(mockServer.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Act
+	posts, _ := pf.WatchFeed(ctx, "testuser", 5*time.Millisecond)
+	<-firstPoll
+
+	mu.Lock()
+	feed = append([]string{
+		`{"data": {"id": "p4", "createdAt": 4}}`,
+		`{"data": {"id": "p3", "createdAt": 3}}`,
+	}, feed...)
+	mu.Unlock()
+
+	var ids []string
+	for post := range posts {
+		ids = append(ids, post.Data.ID)
+		if len(ids) == 2 {
+			cancel()
+		}
+	}
+
+	// Assert
+	assert.Equal(t, []string{"p3", "p4"}, ids)
+}
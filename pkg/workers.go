@@ -0,0 +1,47 @@
+package primfeed
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultMaxConcurrency = 4
+
+// WithMaxConcurrency caps how many requests batch methods such as GetPosts
+// run at once.
+func WithMaxConcurrency(n int) Option {
+	return func(p *Primfeed) {
+		p.maxConcurrency = n
+	}
+}
+
+// forEach calls fn for 0..n-1 on a bounded number of goroutines and waits
+// for them. Once ctx is done, the remaining indexes are still visited so
+// fn can record the error, but it sees the cancelled ctx.
+func (p *Primfeed) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int)) {
+	workers := p.maxConcurrency
+	if workers <= 0 {
+		workers = defaultMaxConcurrency
+	}
+	workers = min(workers, n)
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+}